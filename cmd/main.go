@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"github.com/AleksK1NG/nats-streaming/config"
 	"github.com/AleksK1NG/nats-streaming/internal/server"
+	"github.com/AleksK1NG/nats-streaming/migrations"
 	"github.com/AleksK1NG/nats-streaming/pkg/jaeger"
 	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/AleksK1NG/nats-streaming/pkg/migrator"
 	"github.com/AleksK1NG/nats-streaming/pkg/nats"
 	"github.com/AleksK1NG/nats-streaming/pkg/postgresql"
 	"github.com/AleksK1NG/nats-streaming/pkg/redis"
@@ -44,6 +48,13 @@ func main() {
 	)
 	appLogger.Infof("Success loaded config: %+v", cfg.AppVersion)
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(cfg, appLogger, os.Args[2:]); err != nil {
+			appLogger.Fatalf("runMigrateCommand: %+v", err)
+		}
+		return
+	}
+
 	tracer, closer, err := jaeger.InitJaeger(cfg)
 	if err != nil {
 		appLogger.Fatal("cannot create tracer", err)
@@ -73,13 +84,25 @@ func main() {
 		natsConn.NatsConn().ConnectedServerId(),
 	)
 
-	pgxPool, err := postgresql.NewPgxConn(cfg)
+	pgCluster, err := postgresql.NewCluster(cfg)
 	if err != nil {
-		appLogger.Fatalf("NewPgxConn: %+v", err)
+		appLogger.Fatalf("NewCluster: %+v", err)
+	}
+	defer pgCluster.Close()
+	appLogger.Infof("PostgreSQL connected: %+v, replicas: %d", pgCluster.Primary().Stat().TotalConns(), len(cfg.PostgreSQL.Replicas))
+
+	if cfg.PostgreSQL.AutoMigrate {
+		m, err := migrator.NewMigrator(pgCluster.Primary(), appLogger, migrations.FS)
+		if err != nil {
+			appLogger.Fatalf("NewMigrator: %+v", err)
+		}
+		if err := m.Up(context.Background()); err != nil {
+			appLogger.Fatalf("Migrator.Up: %+v", err)
+		}
+		appLogger.Info("PostgreSQL migrations applied")
 	}
-	appLogger.Infof("PostgreSQL connected: %+v", pgxPool.Stat().TotalConns())
 
-	s := server.NewServer(appLogger, cfg, natsConn, pgxPool, tracer, redisClient)
+	s := server.NewServer(appLogger, cfg, natsConn, pgCluster, tracer, redisClient)
 
 	appLogger.Fatal(s.Run())
 }
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/AleksK1NG/nats-streaming/config"
+	"github.com/AleksK1NG/nats-streaming/migrations"
+	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/AleksK1NG/nats-streaming/pkg/migrator"
+	"github.com/AleksK1NG/nats-streaming/pkg/postgresql"
+	"github.com/pkg/errors"
+)
+
+const migrateUsage = "usage: migrate up | down | to <version> | status"
+
+// runMigrateCommand run migrate subcommand: up, down, to <version> or status
+func runMigrateCommand(cfg *config.Config, log logger.Logger, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	ctx := context.Background()
+
+	pgxPool, err := postgresql.NewPgxConn(cfg)
+	if err != nil {
+		return errors.Wrap(err, "NewPgxConn")
+	}
+	defer pgxPool.Close()
+
+	m, err := migrator.NewMigrator(pgxPool, log, migrations.FS)
+	if err != nil {
+		return errors.Wrap(err, "NewMigrator")
+	}
+
+	switch args[0] {
+	case "up":
+		return m.Up(ctx)
+	case "down":
+		return m.Down(ctx)
+	case "to":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "strconv.ParseInt")
+		}
+		return m.To(ctx, version)
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		log.Infof("Current schema version: %d", status.Version)
+		for _, ms := range status.Migrations {
+			log.Info(ms.String())
+		}
+		return nil
+	}
+
+	return errors.New(migrateUsage)
+}
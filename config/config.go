@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/AleksK1NG/nats-streaming/pkg/constants"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -22,6 +23,11 @@ type Config struct {
 	Redis       Redis
 	MailService MailService
 	PostgreSQL  PostgreSQL
+	Search      Search
+	Retention   Retention
+	Partitions  Partitions
+	Webhooks    Webhooks
+	Health      Health
 }
 
 // HTTP server config
@@ -33,6 +39,7 @@ type HTTP struct {
 	WriteTimeout      time.Duration
 	MaxConnectionIdle time.Duration
 	MaxConnectionAge  time.Duration
+	EventsHeartbeat   time.Duration
 }
 
 // Logger config
@@ -69,11 +76,37 @@ type Redis struct {
 	DB             int
 }
 
-// Nats config
+// Nats config, send email subjects are priority lanes, lower lane message is not sent while higher lanes have backlog,
+// MonitoringURL of nats streaming monitoring endpoint is used to count backlog of all instances
 type Nats struct {
-	URL       string
-	ClusterID string
-	ClientID  string
+	URL           string
+	MonitoringURL string
+	ClusterID     string
+	ClientID      string
+	CreateEmail   Subscription
+	SendEmailHigh Subscription
+	SendEmail     Subscription
+	SendEmailLow  Subscription
+	StatusEvents  StatusEvents
+}
+
+// StatusEvents email status events config, BufferSize last events are kept in memory for watch resumption
+type StatusEvents struct {
+	Subject      string
+	BufferSize   int
+	ReplayWindow time.Duration
+}
+
+// Subscription nats streaming subject subscription config
+type Subscription struct {
+	Subject     string
+	QueueGroup  string
+	DurableName string
+	Workers     int
+	MaxInflight int
+	AckWait     time.Duration
+	RateLimit   float64
+	RateBurst   int
 }
 
 // MailService config
@@ -87,17 +120,76 @@ type MailService struct {
 	KeepAlive      bool
 	ConnectTimeout time.Duration
 	SendTimeout    time.Duration
+	SendingLease   time.Duration
+	Retry          RetryPolicy
 }
 
-// PostgreSQL config
+// RetryPolicy send email retry policy config, intervals in seconds
+type RetryPolicy struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	Jitter          float64
+	MaxAttempts     int
+	MaxAge          time.Duration
+	PollInterval    time.Duration
+	PollBatchSize   int
+}
+
+// PostgreSQL config, Replicas are read replicas DSNs, replica lag settings in seconds,
+// replica which didn't receive message from primary for ReplicaReceiveTimeout is not used, zero disables the check
 type PostgreSQL struct {
-	PostgresqlHost     string
-	PostgresqlPort     string
-	PostgresqlUser     string
-	PostgresqlPassword string
-	PostgresqlDBName   string
-	PostgresqlSSLMode  string
-	PgDriver           string
+	PostgresqlHost        string
+	PostgresqlPort        string
+	PostgresqlUser        string
+	PostgresqlPassword    string
+	PostgresqlDBName      string
+	PostgresqlSSLMode     string
+	PgDriver              string
+	AutoMigrate           bool
+	Replicas              []string
+	MaxReplicaLag         time.Duration
+	ReplicaLagInterval    time.Duration
+	ReplicaReceiveTimeout time.Duration
+}
+
+// Search emails search config, highlight markers wrap matched terms in html escaped snippets,
+// export reads matched emails from db cursor in batches of ExportFetchSize
+type Search struct {
+	HighlightStartSel   string
+	HighlightStopSel    string
+	SnippetMaxWords     int
+	SnippetMinWords     int
+	SnippetMaxFragments int
+	ExportFetchSize     int
+}
+
+// Retention emails retention config, intervals in seconds
+type Retention struct {
+	Enabled    bool
+	Interval   time.Duration
+	BatchSize  int
+	ArchiveDir string
+	Rules      []RetentionRule
+}
+
+// RetentionRule emails older than MaxAge seconds matching status and tenant are archived and deleted,
+// empty status matches only sent and failed emails, empty tenant matches all tenants
+type RetentionRule struct {
+	Name     string
+	Status   string
+	TenantID string
+	MaxAge   time.Duration
+}
+
+// Partitions emails table monthly partitions config, interval in seconds,
+// partitions older than RetainMonths are detached, zero RetainMonths keeps all partitions attached
+type Partitions struct {
+	Enabled       bool
+	Interval      time.Duration
+	PremakeMonths int
+	RetainMonths  int
+	DropDetached  bool
 }
 
 // GRPC gRPC service config
@@ -146,6 +238,10 @@ func ParseConfig() (*Config, error) {
 	if natsUrl != "" {
 		c.Nats.URL = natsUrl
 	}
+	natsMonitoringUrl := os.Getenv(constants.NATS_MONITORING_URL)
+	if natsMonitoringUrl != "" {
+		c.Nats.MonitoringURL = natsMonitoringUrl
+	}
 	natsClientID := os.Getenv(constants.NATS_CLIENT_ID)
 	if natsClientID != "" {
 		c.Nats.ClientID = natsClientID
@@ -229,5 +325,55 @@ func ParseConfig() (*Config, error) {
 		c.MailService.Password = mailPassword
 	}
 
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
 	return &c, nil
 }
+
+// validate check config values which can't fallback to defaults, e.g. intervals of tickers must be positive
+func (c *Config) validate() error {
+	intervals := []struct {
+		name     string
+		interval time.Duration
+		enabled  bool
+	}{
+		{name: "MailService.Retry.PollInterval", interval: c.MailService.Retry.PollInterval, enabled: true},
+		{name: "Retention.Interval", interval: c.Retention.Interval, enabled: c.Retention.Enabled},
+		{name: "Partitions.Interval", interval: c.Partitions.Interval, enabled: c.Partitions.Enabled},
+		{name: "Webhooks.Retry.PollInterval", interval: c.Webhooks.Retry.PollInterval, enabled: c.Webhooks.Enabled},
+		{name: "PostgreSQL.ReplicaLagInterval", interval: c.PostgreSQL.ReplicaLagInterval, enabled: len(c.PostgreSQL.Replicas) > 0},
+		{name: "Health.Interval", interval: c.Health.Interval, enabled: true},
+	}
+
+	for _, i := range intervals {
+		if i.enabled && i.interval <= 0 {
+			return errors.Errorf("config %s must be positive, got: %d", i.name, i.interval)
+		}
+	}
+	return nil
+}
+
+// Webhooks outbound webhooks config, status events are consumed by queue group, so every event is
+// dispatched once per webhook, endpoint is disabled after DisableAfterFailures consecutive failed attempts,
+// only https urls of public addresses are allowed unless AllowHTTP or AllowPrivateNetworks is set
+type Webhooks struct {
+	Enabled              bool
+	QueueGroup           string
+	DurableName          string
+	MaxInflight          int
+	AckWait              time.Duration
+	Timeout              time.Duration
+	Concurrency          int
+	DisableAfterFailures int
+	AllowHTTP            bool
+	AllowPrivateNetworks bool
+	Retry                RetryPolicy
+}
+
+// Health dependency health checks config, in seconds
+type Health struct {
+	Interval time.Duration
+	Timeout  time.Duration
+}
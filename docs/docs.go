@@ -24,9 +24,9 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/email": {
-            "post": {
-                "description": "Create new email and send it",
+        "/admin/workers": {
+            "get": {
+                "description": "Get number of running NATS subscription workers per subject",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,49 +34,206 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Emails"
+                    "Admin"
                 ],
-                "summary": "Create new email",
+                "summary": "Get subscription workers",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Email"
+                            "$ref": "#/definitions/models.WorkersList"
                         }
                     }
                 }
-            }
-        },
-        "/email/search": {
-            "get": {
-                "description": "Search email",
+            },
+            "put": {
+                "description": "Scale up or down running NATS subscription workers of subject",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Scale subscription workers",
+                "parameters": [
+                    {
+                        "description": "subject and workers number",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScaleWorkersReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WorkersList"
+                        }
+                    }
+                }
+            }
+        },
+        "/email/events": {
+            "get": {
+                "description": "Stream email status events as server sent events, event id is its sequence and stream is resumed after Last-Event-ID header or lastEventID query param, stream closed by server ends with error event with error body",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Emails"
+                ],
+                "summary": "Stream email delivery events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "email id",
+                        "name": "emailID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sender address",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "tenant id",
+                        "name": "tenantID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated event types: created, queued, sent, retrying, failed, dead_lettered",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last received event id",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "last received event id",
+                        "name": "lastEventID",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StatusEvent"
+                        }
+                    }
+                }
+            }
+        },
+        "/email/export": {
+            "get": {
+                "description": "Stream all emails matching search filters as csv or json lines file, optionally gzip compressed.\nResponse is not bounded by server write timeout, export failed after file is partially sent aborts connection,\nso client gets read error instead of truncated file",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/gzip"
+                ],
                 "tags": [
                     "Emails"
                 ],
-                "summary": "Search emails",
+                "summary": "Export emails",
                 "parameters": [
                     {
+                        "enum": [
+                            "csv",
+                            "jsonl"
+                        ],
                         "type": "string",
-                        "description": "search text",
+                        "description": "file format, default is csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "gzip compress file",
+                        "name": "gzip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search text: words, quoted phrases, AND, OR, NOT, -word, (groups) and word* prefix",
                         "name": "search",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "page number",
-                        "name": "page",
+                        "description": "text search configuration of search text, e.g. english, default is language of each email",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sender address",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "number of elements",
-                        "name": "size",
+                        "description": "recipient address",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "queued",
+                            "retrying",
+                            "sent",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "subject prefix",
+                        "name": "subjectPrefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or after, RFC3339",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created before, RFC3339",
+                        "name": "createdTo",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "createdAt",
+                            "updatedAt",
+                            "subject",
+                            "from",
+                            "to",
+                            "status",
+                            "priority",
+                            "rank"
+                        ],
+                        "type": "string",
+                        "description": "sort field with optional direction, e.g. createdAt:desc, rank requires search text",
+                        "name": "orderBy",
                         "in": "query"
                     }
                 ],
@@ -84,15 +241,15 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.EmailsList"
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/email/{email_id}": {
+        "/webhooks": {
             "get": {
-                "description": "Get email by email uuid",
+                "description": "Get all webhook subscriptions",
                 "consumes": [
                     "application/json"
                 ],
@@ -100,23 +257,182 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Emails"
+                    "Webhooks"
                 ],
-                "summary": "Get email by id",
+                "summary": "Get webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhooksList"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create webhook subscription to email delivery events, secret is generated if not set and returned only in this response, url must be https url of public address, redirects are not followed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create webhook",
+                "parameters": [
+                    {
+                        "description": "url, event types and secret",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhook_id}": {
+            "get": {
+                "description": "Get webhook subscription by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get webhook by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook_id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update webhook url, event types, secret and enabled state, enabling webhook resets its consecutive failures",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update webhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "email_id",
-                        "name": "email_id",
+                        "description": "webhook_id",
+                        "name": "webhook_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "webhook fields",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateWebhookReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Email"
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete webhook subscription with its delivery log",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook_id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhook_id}/deliveries": {
+            "get": {
+                "description": "Get last deliveries of webhook with result of last attempt, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook_id",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of deliveries, 50 by default, at most 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDeliveriesList"
                         }
                     }
                 }
@@ -124,58 +440,224 @@ var doc = `{
         }
     },
     "definitions": {
-        "models.Email": {
+        "models.DeliveryError": {
+            "type": "object",
+            "properties": {
+                "class": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "integer"
+                },
+                "enhancedCode": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ScaleWorkersReq": {
             "type": "object",
             "required": [
-                "from",
-                "message",
-                "subject",
-                "to"
+                "subject"
             ],
             "properties": {
-                "createdAt": {
+                "subject": {
                     "type": "string"
                 },
+                "workers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.StatusEvent": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
                 "emailID": {
                     "type": "string"
                 },
                 "from": {
                     "type": "string"
                 },
-                "message": {
+                "lastError": {
+                    "$ref": "#/definitions/models.DeliveryError"
+                },
+                "occurredAt": {
                     "type": "string"
                 },
-                "subject": {
+                "sequence": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tenantID": {
                     "type": "string"
                 },
                 "to": {
                     "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "models.EmailsList": {
+        "models.SubscriptionWorkers": {
             "type": "object",
             "properties": {
-                "emails": {
+                "subject": {
+                    "type": "string"
+                },
+                "workers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UpdateWebhookReq": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "eventTypes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Email"
+                        "type": "string"
                     }
                 },
-                "hasMore": {
-                    "type": "boolean"
+                "secret": {
+                    "type": "string"
                 },
-                "page": {
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Webhook": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "consecutiveFailures": {
                     "type": "integer"
                 },
-                "size": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "disabledAt": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "webhookID": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDeliveriesList": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookDelivery"
+                    }
+                },
+                "webhookID": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
                     "type": "integer"
                 },
-                "totalCount": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "deliveryID": {
+                    "type": "string"
+                },
+                "emailID": {
+                    "type": "string"
+                },
+                "eventSequence": {
                     "type": "integer"
                 },
-                "totalPages": {
+                "eventType": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "lastStatusCode": {
                     "type": "integer"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "webhookID": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhooksList": {
+            "type": "object",
+            "properties": {
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Webhook"
+                    }
+                }
+            }
+        },
+        "models.WorkersList": {
+            "type": "object",
+            "properties": {
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SubscriptionWorkers"
+                    }
                 }
             }
         }
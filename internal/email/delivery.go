@@ -5,6 +5,9 @@ import "github.com/labstack/echo/v4"
 // HTTPDelivery interface
 type HTTPDelivery interface {
 	Create() echo.HandlerFunc
+	CreateBatch() echo.HandlerFunc
 	GetByID() echo.HandlerFunc
 	Search() echo.HandlerFunc
+	GetDeliveryHistory() echo.HandlerFunc
+	Erase() echo.HandlerFunc
 }
@@ -2,18 +2,28 @@ package grpc
 
 import (
 	"context"
+	"fmt"
+	"io"
 
 	"github.com/AleksK1NG/nats-streaming/internal/email"
 	"github.com/AleksK1NG/nats-streaming/internal/models"
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
 	grpcErrors "github.com/AleksK1NG/nats-streaming/pkg/grpc_errors"
 	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	natsConn "github.com/AleksK1NG/nats-streaming/pkg/nats"
 	"github.com/AleksK1NG/nats-streaming/pkg/utils"
 	"github.com/AleksK1NG/nats-streaming/proto/email"
 	"github.com/go-playground/validator/v10"
 	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// exportChunkSize max size of export file chunk sent to stream
+const exportChunkSize = 64 * 1024
+
 type emailGRPCService struct {
 	emailUC   email.UseCase
 	log       logger.Logger
@@ -31,12 +41,7 @@ func (e *emailGRPCService) Create(ctx context.Context, req *emailService.CreateR
 	defer span.Finish()
 	createRequests.Inc()
 
-	m := &models.Email{
-		From:    req.GetFrom(),
-		To:      req.GetTo(),
-		Subject: req.GetSubject(),
-		Message: req.GetMessage(),
-	}
+	m := emailFromProto(req)
 
 	if err := e.validator.StructCtx(ctx, m); err != nil {
 		errorRequests.Inc()
@@ -54,6 +59,113 @@ func (e *emailGRPCService) Create(ctx context.Context, req *emailService.CreateR
 	return &emailService.CreateRes{Status: "Ok"}, nil
 }
 
+// CreateBatch create emails batch, every email is validated separately and gets its own result
+func (e *emailGRPCService) CreateBatch(ctx context.Context, req *emailService.CreateBatchReq) (*emailService.CreateBatchRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productService.CreateBatch")
+	defer span.Finish()
+	createBatchRequests.Inc()
+
+	if len(req.GetEmails()) == 0 || len(req.GetEmails()) > models.MaxBatchSize {
+		errorRequests.Inc()
+		err := errors.Errorf("emails batch size must be between 1 and %d", models.MaxBatchSize)
+		e.log.Errorf("CreateBatch: %v", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result := models.NewBatchResult(len(req.GetEmails()))
+	valid := make([]*models.Email, 0, len(req.GetEmails()))
+	indexes := make([]int, 0, len(req.GetEmails()))
+	for i, item := range req.GetEmails() {
+		m := emailFromProto(item)
+		if err := e.validator.StructCtx(ctx, m); err != nil {
+			result.SetFailed(i, err)
+			continue
+		}
+		valid = append(valid, m)
+		indexes = append(indexes, i)
+	}
+
+	if len(valid) > 0 {
+		created, err := e.emailUC.CreateBatch(ctx, valid)
+		if err != nil {
+			errorRequests.Inc()
+			e.log.Errorf("emailUC.CreateBatch: %v", err)
+			return nil, grpcErrors.ErrorResponse(err, err.Error())
+		}
+		for i, m := range created {
+			result.SetCreated(indexes[i], m.EmailID)
+		}
+	}
+
+	successRequests.Inc()
+	return result.ToProto(), nil
+}
+
+// CreateStream create emails streamed by client and return summary when stream is closed,
+// emails are created in chunks of publish acks inflight limit and next messages are not received until chunk is acked,
+// so gRPC flow control applies backpressure to client
+func (e *emailGRPCService) CreateStream(stream emailService.EmailService_CreateStreamServer) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "productService.CreateStream")
+	defer span.Finish()
+	createStreamRequests.Inc()
+
+	res := &emailService.CreateStreamRes{EmailIDs: make([]string, 0), Rejections: make([]*emailService.BatchItemResult, 0)}
+	chunk := make([]*models.Email, 0, natsConn.MaxPubAcksInflight)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		created, err := e.emailUC.CreateBatch(ctx, chunk)
+		if err != nil {
+			return errors.Wrap(err, "emailUC.CreateBatch")
+		}
+		for _, m := range created {
+			res.EmailIDs = append(res.EmailIDs, m.EmailID.String())
+		}
+		res.Accepted += int64(len(created))
+		chunk = chunk[:0]
+		return nil
+	}
+
+	for index := int64(0); ; index++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errorRequests.Inc()
+			e.log.Errorf("stream.Recv: %v", err)
+			return err
+		}
+
+		m := emailFromProto(req)
+		if err := e.validator.StructCtx(ctx, m); err != nil {
+			res.Rejected++
+			res.Rejections = append(res.Rejections, &emailService.BatchItemResult{Index: index, Error: err.Error()})
+			continue
+		}
+
+		chunk = append(chunk, m)
+		if len(chunk) < natsConn.MaxPubAcksInflight {
+			continue
+		}
+		if err := flush(); err != nil {
+			errorRequests.Inc()
+			e.log.Errorf("CreateStream flush: %v", err)
+			return grpcErrors.ErrorResponse(err, fmt.Sprintf("accepted before error: %d, error: %v", res.Accepted, err))
+		}
+	}
+
+	if err := flush(); err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("CreateStream flush: %v", err)
+		return grpcErrors.ErrorResponse(err, fmt.Sprintf("accepted before error: %d, error: %v", res.Accepted, err))
+	}
+
+	successRequests.Inc()
+	return stream.SendAndClose(res)
+}
+
 // GetByID find single email by id
 func (e *emailGRPCService) GetByID(ctx context.Context, req *emailService.GetByIDReq) (*emailService.GetByIDRes, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "productService.GetByID")
@@ -64,7 +176,7 @@ func (e *emailGRPCService) GetByID(ctx context.Context, req *emailService.GetByI
 	if err != nil {
 		errorRequests.Inc()
 		e.log.Errorf("uuid.FromString: %v", err)
-		return nil, grpcErrors.ErrorResponse(err, err.Error())
+		return nil, grpcErrors.ErrorResponse(domainErrors.NewFieldError("EmailID", err), err.Error())
 	}
 
 	m, err := e.emailUC.GetByID(ctx, emailUUID)
@@ -78,13 +190,66 @@ func (e *emailGRPCService) GetByID(ctx context.Context, req *emailService.GetByI
 	return &emailService.GetByIDRes{Email: m.ToProto()}, nil
 }
 
+// Update edit recipient, subject or message of email which is not sending yet
+func (e *emailGRPCService) Update(ctx context.Context, req *emailService.UpdateReq) (*emailService.UpdateRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productService.Update")
+	defer span.Finish()
+	updateRequests.Inc()
+
+	emailUUID, err := uuid.FromString(req.GetEmailID())
+	if err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("uuid.FromString: %v", err)
+		return nil, grpcErrors.ErrorResponse(domainErrors.NewFieldError("EmailID", err), err.Error())
+	}
+
+	updateReq := updateEmailReqFromProto(req)
+	if err := e.validator.StructCtx(ctx, updateReq); err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("validator.StructCtx: %v", err)
+		return nil, grpcErrors.ErrorResponse(err, err.Error())
+	}
+
+	m, err := e.emailUC.Update(ctx, emailUUID, updateReq)
+	if err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("emailUC.Update: %v", err)
+		return nil, grpcErrors.ErrorResponse(err, err.Error())
+	}
+
+	successRequests.Inc()
+	return &emailService.UpdateRes{Email: m.ToProto()}, nil
+}
+
 // Search find email by search text
 func (e *emailGRPCService) Search(ctx context.Context, req *emailService.SearchReq) (*emailService.SearchRes, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "productService.Search")
 	defer span.Finish()
 	searchRequests.Inc()
 
-	res, err := e.emailUC.Search(ctx, req.GetSearch(), utils.NewPaginationQuery(int(req.GetSize()), int(req.GetPage())))
+	pq, err := utils.NewPaginationQuery(int(req.GetSize()), int(req.GetPage()))
+	if err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("utils.NewPaginationQuery: %v", err)
+		return nil, grpcErrors.ErrorResponse(err, err.Error())
+	}
+	pq.SetOrderBy(req.GetOrderBy())
+
+	query, err := models.NewSearchQuery(
+		req.GetSearch(),
+		req.GetLanguage(),
+		searchFilterFromProto(req),
+		pq,
+		req.GetCursor(),
+		req.GetSkipTotalCount(),
+	)
+	if err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("models.NewSearchQuery: %v", err)
+		return nil, grpcErrors.ErrorResponse(err, err.Error())
+	}
+
+	res, err := e.emailUC.Search(ctx, query)
 	if err != nil {
 		errorRequests.Inc()
 		e.log.Errorf("emailUC.GetByID: %v", err)
@@ -98,5 +263,239 @@ func (e *emailGRPCService) Search(ctx context.Context, req *emailService.SearchR
 		Size:       res.Size,
 		HasMore:    res.HasMore,
 		Emails:     res.ToProto(),
+		NextCursor: res.NextCursor,
 	}, nil
 }
+
+// Export stream emails matching search filters as csv or json lines file chunks, pagination of query is ignored
+func (e *emailGRPCService) Export(req *emailService.ExportReq, stream emailService.EmailService_ExportServer) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "productService.Export")
+	defer span.Finish()
+	exportRequests.Inc()
+
+	pq := &utils.Pagination{}
+	pq.SetOrderBy(req.GetQuery().GetOrderBy())
+
+	searchQuery, err := models.NewSearchQuery(
+		req.GetQuery().GetSearch(),
+		req.GetQuery().GetLanguage(),
+		searchFilterFromProto(req.GetQuery()),
+		pq,
+		"",
+		true,
+	)
+	if err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("models.NewSearchQuery: %v", err)
+		return grpcErrors.ErrorResponse(err, err.Error())
+	}
+
+	query, err := models.NewExportQuery(searchQuery, req.GetFormat(), req.GetGzip())
+	if err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("models.NewExportQuery: %v", err)
+		return grpcErrors.ErrorResponse(err, err.Error())
+	}
+
+	w := &exportChunkWriter{stream: stream}
+	if _, err := e.emailUC.Export(ctx, query, w); err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("emailUC.Export: %v", err)
+		return grpcErrors.ErrorResponse(err, err.Error())
+	}
+	if err := w.Close(); err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("exportChunkWriter.Close: %v", err)
+		return err
+	}
+
+	successRequests.Inc()
+	return nil
+}
+
+// GetDeliveryHistory get all send attempts of email
+func (e *emailGRPCService) GetDeliveryHistory(ctx context.Context, req *emailService.GetDeliveryHistoryReq) (*emailService.GetDeliveryHistoryRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productService.GetDeliveryHistory")
+	defer span.Finish()
+	getDeliveryHistoryRequests.Inc()
+
+	emailUUID, err := uuid.FromString(req.GetEmailID())
+	if err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("uuid.FromString: %v", err)
+		return nil, grpcErrors.ErrorResponse(domainErrors.NewFieldError("EmailID", err), err.Error())
+	}
+
+	history, err := e.emailUC.GetDeliveryHistory(ctx, emailUUID)
+	if err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("emailUC.GetDeliveryHistory: %v", err)
+		return nil, grpcErrors.ErrorResponse(err, err.Error())
+	}
+
+	successRequests.Inc()
+	return &emailService.GetDeliveryHistoryRes{EmailID: history.EmailID.String(), Attempts: history.ToProto()}, nil
+}
+
+// Erase delete or redact all data of email address
+func (e *emailGRPCService) Erase(ctx context.Context, req *emailService.EraseReq) (*emailService.EraseRes, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "productService.Erase")
+	defer span.Finish()
+	eraseRequests.Inc()
+
+	erasureReq := &models.ErasureReq{Address: req.GetAddress(), Mode: req.GetMode()}
+	if err := e.validator.StructCtx(ctx, erasureReq); err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("validator.StructCtx: %v", err)
+		return nil, grpcErrors.ErrorResponse(err, err.Error())
+	}
+
+	report, err := e.emailUC.Erase(ctx, erasureReq)
+	if err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("emailUC.Erase: %v", err)
+		return nil, grpcErrors.ErrorResponse(err, err.Error())
+	}
+
+	successRequests.Inc()
+	return report.ToProto(), nil
+}
+
+// WatchStatus stream status transitions of email or emails of sender or tenant,
+// watch of single email starts with its current status unless it is resumed after last seen sequence
+func (e *emailGRPCService) WatchStatus(req *emailService.WatchStatusReq, stream emailService.EmailService_WatchStatusServer) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "productService.WatchStatus")
+	defer span.Finish()
+	watchStatusRequests.Inc()
+
+	filter, err := models.NewStatusFilter(req.GetEmailID(), req.GetFrom(), req.GetTenantID(), req.GetTypes())
+	if err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("models.NewStatusFilter: %v", err)
+		return grpcErrors.ErrorResponse(err, err.Error())
+	}
+	if filter.Empty() {
+		errorRequests.Inc()
+		err := errors.Wrap(models.ErrInvalidStatusFilter, "emailID, from or tenantID is required")
+		return grpcErrors.ErrorResponse(err, err.Error())
+	}
+
+	// subscribe before reading snapshot, so no transition is lost between them
+	sub, err := e.emailUC.WatchStatus(ctx, filter, req.GetAfterSequence())
+	if err != nil {
+		errorRequests.Inc()
+		e.log.Errorf("emailUC.WatchStatus: %v", err)
+		return grpcErrors.ErrorResponse(err, err.Error())
+	}
+	defer sub.Close()
+
+	if !uuid.Equal(filter.EmailID, uuid.Nil) && req.GetAfterSequence() == 0 {
+		m, err := e.emailUC.GetByID(ctx, filter.EmailID)
+		if err != nil {
+			errorRequests.Inc()
+			e.log.Errorf("emailUC.GetByID: %v", err)
+			return grpcErrors.ErrorResponse(err, err.Error())
+		}
+		if err := stream.Send(models.NewStatusEvent(models.EventTypeSnapshot, m).ToProto()); err != nil {
+			e.log.Errorf("stream.Send: %v", err)
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			successRequests.Inc()
+			return nil
+		case event, ok := <-sub.C:
+			if !ok {
+				errorRequests.Inc()
+				e.log.Errorf("WatchStatus subscription closed: %v", sub.Err())
+				return grpcErrors.ErrorResponse(sub.Err(), sub.Err().Error())
+			}
+			if err := stream.Send(event.ToProto()); err != nil {
+				e.log.Errorf("stream.Send: %v", err)
+				return err
+			}
+		}
+	}
+}
+
+func searchFilterFromProto(req *emailService.SearchReq) models.SearchFilter {
+	filter := models.SearchFilter{
+		From:          req.GetFrom(),
+		To:            req.GetTo(),
+		Status:        req.GetStatus(),
+		SubjectPrefix: req.GetSubjectPrefix(),
+	}
+	if req.GetCreatedFrom() != nil {
+		createdFrom := req.GetCreatedFrom().AsTime()
+		filter.CreatedFrom = &createdFrom
+	}
+	if req.GetCreatedTo() != nil {
+		createdTo := req.GetCreatedTo().AsTime()
+		filter.CreatedTo = &createdTo
+	}
+	return filter
+}
+
+// updateEmailReqFromProto unset wrapper fields are not changed
+func updateEmailReqFromProto(req *emailService.UpdateReq) *models.UpdateEmailReq {
+	updateReq := &models.UpdateEmailReq{Version: int(req.GetVersion())}
+	if req.GetTo() != nil {
+		updateReq.To = &req.GetTo().Value
+	}
+	if req.GetSubject() != nil {
+		updateReq.Subject = &req.GetSubject().Value
+	}
+	if req.GetMessage() != nil {
+		updateReq.Message = &req.GetMessage().Value
+	}
+	return updateReq
+}
+
+func emailFromProto(req *emailService.CreateReq) *models.Email {
+	return &models.Email{
+		From:     req.GetFrom(),
+		To:       req.GetTo(),
+		Subject:  req.GetSubject(),
+		Message:  req.GetMessage(),
+		Priority: req.GetPriority(),
+		Language: req.GetLanguage(),
+		TenantID: req.GetTenantID(),
+	}
+}
+
+// exportChunkWriter sends written export file to stream in chunks of exportChunkSize
+type exportChunkWriter struct {
+	stream emailService.EmailService_ExportServer
+	buf    []byte
+}
+
+func (w *exportChunkWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for len(w.buf) >= exportChunkSize {
+		if err := w.send(w.buf[:exportChunkSize]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[exportChunkSize:]
+	}
+	return len(p), nil
+}
+
+// Close sends remaining buffered data
+func (w *exportChunkWriter) Close() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.send(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (w *exportChunkWriter) send(data []byte) error {
+	// chunk is copied, because buffer is reused after send
+	chunk := make([]byte, len(data))
+	copy(chunk, data)
+	return errors.Wrap(w.stream.Send(&emailService.ExportChunk{Data: chunk}), "stream.Send")
+}
@@ -22,8 +22,36 @@ var (
 		Name: "grpc_email_get_by_id_incoming_requests_total",
 		Help: "The total number of incoming get by id email GRPC requests",
 	})
+	updateRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "grpc_email_update_incoming_requests_total",
+		Help: "The total number of incoming update email GRPC requests",
+	})
 	searchRequests = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "grpc_email_search_incoming_requests_total",
 		Help: "The total number of incoming search email GRPC requests",
 	})
+	exportRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "grpc_email_export_incoming_requests_total",
+		Help: "The total number of incoming export email GRPC requests",
+	})
+	getDeliveryHistoryRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "grpc_email_get_delivery_history_incoming_requests_total",
+		Help: "The total number of incoming get delivery history email GRPC requests",
+	})
+	eraseRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "grpc_email_erase_incoming_requests_total",
+		Help: "The total number of incoming erase email GRPC requests",
+	})
+	createBatchRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "grpc_email_create_batch_incoming_requests_total",
+		Help: "The total number of incoming create batch email GRPC requests",
+	})
+	createStreamRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "grpc_email_create_stream_incoming_requests_total",
+		Help: "The total number of incoming create stream email GRPC requests",
+	})
+	watchStatusRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "grpc_email_watch_status_incoming_requests_total",
+		Help: "The total number of incoming watch status email GRPC requests",
+	})
 )
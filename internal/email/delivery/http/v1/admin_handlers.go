@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/AleksK1NG/nats-streaming/internal/email/delivery/nats"
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	httpErrors "github.com/AleksK1NG/nats-streaming/pkg/http_errors"
+	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+type adminHandlers struct {
+	group    *echo.Group
+	scaler   nats.WorkersScaler
+	log      logger.Logger
+	validate *validator.Validate
+}
+
+// NewAdminHandlers adminHandlers constructor
+func NewAdminHandlers(group *echo.Group, scaler nats.WorkersScaler, log logger.Logger, validate *validator.Validate) *adminHandlers {
+	return &adminHandlers{group: group, scaler: scaler, log: log, validate: validate}
+}
+
+// GetWorkers GetWorkers
+// @Tags Admin
+// @Summary Get subscription workers
+// @Description Get number of running NATS subscription workers per subject
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.WorkersList
+// @Router /admin/workers [get]
+func (h *adminHandlers) GetWorkers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, _ := opentracing.StartSpanFromContext(c.Request().Context(), "adminHandlers.GetWorkers")
+		defer span.Finish()
+
+		return c.JSON(http.StatusOK, models.NewWorkersList(h.scaler.Workers()))
+	}
+}
+
+// ScaleWorkers ScaleWorkers
+// @Tags Admin
+// @Summary Scale subscription workers
+// @Description Scale up or down running NATS subscription workers of subject
+// @Accept json
+// @Produce json
+// @Param body body models.ScaleWorkersReq true "subject and workers number"
+// @Success 200 {object} models.WorkersList
+// @Router /admin/workers [put]
+func (h *adminHandlers) ScaleWorkers() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "adminHandlers.ScaleWorkers")
+		defer span.Finish()
+
+		var req models.ScaleWorkersReq
+		if err := c.Bind(&req); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		if err := h.validate.StructCtx(ctx, &req); err != nil {
+			h.log.Errorf("validate.StructCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		if err := h.scaler.ScaleWorkers(req.Subject, req.Workers); err != nil {
+			h.log.Errorf("scaler.ScaleWorkers: %v", err)
+			switch {
+			case errors.Is(err, nats.ErrSubjectNotFound):
+				return httpErrors.ErrorCtxResponse(c, httpErrors.NewNotFoundError(err.Error()))
+			case errors.Is(err, nats.ErrInvalidWorkersNum):
+				return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+			}
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		h.log.Infof("Scaled workers, subject: %s, workers: %d", req.Subject, req.Workers)
+		return c.JSON(http.StatusOK, models.NewWorkersList(h.scaler.Workers()))
+	}
+}
@@ -1,144 +1,290 @@
 package v1
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/AleksK1NG/nats-streaming/config"
 	"github.com/AleksK1NG/nats-streaming/internal/email"
 	"github.com/AleksK1NG/nats-streaming/internal/models"
 	httpErrors "github.com/AleksK1NG/nats-streaming/pkg/http_errors"
 	"github.com/AleksK1NG/nats-streaming/pkg/logger"
 	"github.com/AleksK1NG/nats-streaming/pkg/utils"
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/opentracing/opentracing-go"
-	uuid "github.com/satori/go.uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	// eventsRetry reconnect delay hint for event stream clients, milliseconds
+	eventsRetry = 1000
+	// eventTypeError event closing stream, data is REST error body
+	eventTypeError = "error"
 )
 
 type emailHandlers struct {
-	group    *echo.Group
-	emailUC  email.UseCase
-	log      logger.Logger
-	validate *validator.Validate
+	group   *echo.Group
+	emailUC email.UseCase
+	log     logger.Logger
+	cfg     *config.Config
 }
 
 // NewEmailHandlers emailHandlers constructor
-func NewEmailHandlers(group *echo.Group, emailUC email.UseCase, log logger.Logger, validate *validator.Validate) *emailHandlers {
-	return &emailHandlers{group: group, emailUC: emailUC, log: log, validate: validate}
+func NewEmailHandlers(group *echo.Group, emailUC email.UseCase, log logger.Logger, cfg *config.Config) *emailHandlers {
+	return &emailHandlers{group: group, emailUC: emailUC, log: log, cfg: cfg}
 }
 
-// Create Create
+// Export Export
 // @Tags Emails
-// @Summary Create new email
-// @Description Create new email and send it
-// @Accept json
-// @Produce json
-// @Success 201 {object} models.Email
-// @Router /email [post]
-func (h *emailHandlers) Create() echo.HandlerFunc {
+// @Summary Export emails
+// @Description Stream all emails matching search filters as csv or json lines file, optionally gzip compressed.
+// @Description Response is not bounded by server write timeout, export failed after file is partially sent aborts connection,
+// @Description so client gets read error instead of truncated file
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/gzip
+// @Param format query string false "file format, default is csv" Enums(csv, jsonl)
+// @Param gzip query bool false "gzip compress file"
+// @Param search query string false "search text: words, quoted phrases, AND, OR, NOT, -word, (groups) and word* prefix"
+// @Param language query string false "text search configuration of search text, e.g. english, default is language of each email"
+// @Param from query string false "sender address"
+// @Param to query string false "recipient address"
+// @Param status query string false "delivery status" Enums(queued, retrying, sent, failed)
+// @Param subjectPrefix query string false "subject prefix"
+// @Param createdFrom query string false "created at or after, RFC3339"
+// @Param createdTo query string false "created before, RFC3339"
+// @Param orderBy query string false "sort field with optional direction, e.g. createdAt:desc, rank requires search text" Enums(createdAt, updatedAt, subject, from, to, status, priority, rank)
+// @Success 200 {file} file
+// @Router /email/export [get]
+func (h *emailHandlers) Export() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "emailHandlers.Create")
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "emailHandlers.Export")
 		defer span.Finish()
-		createRequests.Inc()
+		exportRequests.Inc()
 
-		var mail models.Email
-		if err := c.Bind(&mail); err != nil {
+		pq := &utils.Pagination{}
+		pq.SetOrderBy(c.QueryParam("orderBy"))
+
+		filter, err := searchFilterFromQuery(c)
+		if err != nil {
+			h.log.Errorf("searchFilterFromQuery: %v", err)
 			errorRequests.Inc()
-			h.log.Errorf("c.Bind: %v", err)
-			return httpErrors.ErrorCtxResponse(c, err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
 		}
 
-		if err := h.validate.StructCtx(ctx, &mail); err != nil {
-			errorRequests.Inc()
-			h.log.Errorf("validate.StructCtx: %v", err)
-			return httpErrors.ErrorCtxResponse(c, err)
+		compress := false
+		if gzipParam := c.QueryParam("gzip"); gzipParam != "" {
+			compress, err = strconv.ParseBool(gzipParam)
+			if err != nil {
+				h.log.Errorf("strconv.ParseBool: %v", err)
+				errorRequests.Inc()
+				return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+			}
 		}
 
-		if err := h.emailUC.PublishCreate(ctx, &mail); err != nil {
+		searchQuery, err := models.NewSearchQuery(c.QueryParam("search"), c.QueryParam("language"), filter, pq, "", true)
+		if err != nil {
+			h.log.Errorf("models.NewSearchQuery: %v", err)
 			errorRequests.Inc()
-			h.log.Errorf("emailUC.PublishCreate: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err)
 		}
 
-		successRequests.Inc()
-		return c.NoContent(http.StatusCreated)
-	}
-}
-
-// GetByID GetByID
-// @Tags Emails
-// @Summary Get email by id
-// @Description Get email by email uuid
-// @Accept json
-// @Produce json
-// @Param email_id path string true "email_id"
-// @Success 200 {object} models.Email
-// @Router /email/{email_id} [get]
-func (h *emailHandlers) GetByID() echo.HandlerFunc {
-	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "emailHandlers.GetByID")
-		defer span.Finish()
-		getByIdRequests.Inc()
-
-		emailUUID, err := uuid.FromString(c.Param("email_id"))
+		query, err := models.NewExportQuery(searchQuery, c.QueryParam("format"), compress)
 		if err != nil {
+			h.log.Errorf("models.NewExportQuery: %v", err)
 			errorRequests.Inc()
-			h.log.Errorf("uuid.FromString: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err)
 		}
 
-		m, err := h.emailUC.GetByID(ctx, emailUUID)
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, query.ContentType())
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", query.FileName(time.Now())))
+		res.Header().Set("X-Accel-Buffering", "no")
+
+		count, err := h.emailUC.Export(ctx, query, res)
 		if err != nil {
+			h.log.Errorf("emailUC.Export: %v", err)
 			errorRequests.Inc()
-			h.log.Errorf("emailUC.GetByID: %v", err)
+			if res.Committed {
+				// file is already partially sent, connection is aborted so client does not take truncated file as complete
+				panic(http.ErrAbortHandler)
+			}
+			res.Header().Del(echo.HeaderContentType)
+			res.Header().Del(echo.HeaderContentDisposition)
 			return httpErrors.ErrorCtxResponse(c, err)
 		}
 
+		h.log.Infof("exported emails: %d", count)
 		successRequests.Inc()
-		return c.JSON(http.StatusOK, m)
+		return nil
 	}
 }
 
-// Search Search emails
+// Events Events
 // @Tags Emails
-// @Summary Search emails
-// @Description Search email
-// @Accept json
-// @Produce json
-// @Param search query string false "search text"
-// @Param page query string false "page number"
-// @Param size query string false "number of elements"
-// @Success 200 {object} models.EmailsList
-// @Router /email/search [get]
-func (h *emailHandlers) Search() echo.HandlerFunc {
+// @Summary Stream email delivery events
+// @Description Stream email status events as server sent events, event id is its sequence and stream is resumed after Last-Event-ID header or lastEventID query param, stream closed by server ends with error event with error body
+// @Produce text/event-stream
+// @Param emailID query string false "email id"
+// @Param from query string false "sender address"
+// @Param tenantID query string false "tenant id"
+// @Param types query string false "comma separated event types: created, queued, sent, retrying, failed, dead_lettered"
+// @Param Last-Event-ID header string false "last received event id"
+// @Param lastEventID query string false "last received event id"
+// @Success 200 {object} models.StatusEvent
+// @Router /email/events [get]
+func (h *emailHandlers) Events() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "emailHandlers.Search")
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "emailHandlers.Events")
 		defer span.Finish()
-		searchRequests.Inc()
+		eventsRequests.Inc()
 
-		page, err := strconv.Atoi(c.QueryParam("page"))
+		filter, err := statusFilterFromQuery(c)
 		if err != nil {
-			h.log.Errorf("strconv.Atoi: %v", err)
 			errorRequests.Inc()
+			h.log.Errorf("statusFilterFromQuery: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err)
 		}
-		size, err := strconv.Atoi(c.QueryParam("size"))
+
+		lastEventID, err := lastEventIDFromRequest(c)
 		if err != nil {
-			h.log.Errorf("strconv.Atoi: %v", err)
 			errorRequests.Inc()
-			return httpErrors.ErrorCtxResponse(c, err)
+			h.log.Errorf("lastEventIDFromRequest: %v", err)
+			return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
 		}
 
-		pq := utils.NewPaginationQuery(size, page)
-
-		res, err := h.emailUC.Search(ctx, c.QueryParam("search"), pq)
+		sub, err := h.emailUC.WatchStatus(ctx, filter, lastEventID)
 		if err != nil {
-			h.log.Errorf("emailUC.Search: %v", err)
 			errorRequests.Inc()
+			h.log.Errorf("emailUC.WatchStatus: %v", err)
 			return httpErrors.ErrorCtxResponse(c, err)
 		}
+		defer sub.Close()
 
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "text/event-stream")
+		res.Header().Set("Cache-Control", "no-cache")
+		res.Header().Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+		if _, err := fmt.Fprintf(res, "retry: %d\n\n", eventsRetry); err != nil {
+			h.log.Errorf("write events retry: %v", err)
+			return nil
+		}
+		res.Flush()
 		successRequests.Inc()
-		return c.JSON(http.StatusOK, res)
+
+		var heartbeat <-chan time.Time
+		if h.cfg.HTTP.EventsHeartbeat > 0 {
+			ticker := time.NewTicker(h.cfg.HTTP.EventsHeartbeat * time.Second)
+			defer ticker.Stop()
+			heartbeat = ticker.C
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-heartbeat:
+				if _, err := io.WriteString(res, ": heartbeat\n\n"); err != nil {
+					h.log.Errorf("write events heartbeat: %v", err)
+					return nil
+				}
+				res.Flush()
+			case event, ok := <-sub.C:
+				if !ok {
+					h.log.Errorf("Events subscription closed: %v", sub.Err())
+					if err := writeErrorEvent(res, sub.Err()); err != nil {
+						h.log.Errorf("writeErrorEvent: %v", err)
+					}
+					return nil
+				}
+				if err := writeStatusEvent(res, event); err != nil {
+					h.log.Errorf("writeStatusEvent: %v", err)
+					return nil
+				}
+				res.Flush()
+			}
+		}
+	}
+}
+
+func writeStatusEvent(w io.Writer, event *models.StatusEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, event.Type, data)
+	return err
+}
+
+// writeErrorEvent status is already sent, so error closing stream is sent as event with REST error body,
+// client resumes after Last-Event-ID when it is retryable
+func writeErrorEvent(w io.Writer, err error) error {
+	if err == nil {
+		return nil
+	}
+	restErr := httpErrors.ParseErrors(err).ErrBody()
+	data, err := json.Marshal(restErr)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+	retry := eventsRetry
+	if restErr.ErrRetryAfter > 0 {
+		retry = restErr.ErrRetryAfter * 1000
+	}
+	_, err = fmt.Fprintf(w, "retry: %d\nevent: %s\ndata: %s\n\n", retry, eventTypeError, data)
+	return err
+}
+
+func statusFilterFromQuery(c echo.Context) (models.StatusFilter, error) {
+	var types []string
+	if typesParam := c.QueryParam("types"); typesParam != "" {
+		types = strings.Split(typesParam, ",")
+	}
+	return models.NewStatusFilter(c.QueryParam("emailID"), c.QueryParam("from"), c.QueryParam("tenantID"), types)
+}
+
+// lastEventIDFromRequest browsers send Last-Event-ID header on reconnect only, so first connection may pass query param
+func lastEventIDFromRequest(c echo.Context) (uint64, error) {
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("lastEventID")
+	}
+	if lastEventID == "" {
+		return 0, nil
+	}
+	sequence, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "Last-Event-ID")
+	}
+	return sequence, nil
+}
+
+func searchFilterFromQuery(c echo.Context) (models.SearchFilter, error) {
+	filter := models.SearchFilter{
+		From:          c.QueryParam("from"),
+		To:            c.QueryParam("to"),
+		Status:        c.QueryParam("status"),
+		SubjectPrefix: c.QueryParam("subjectPrefix"),
+	}
+	if createdFrom := c.QueryParam("createdFrom"); createdFrom != "" {
+		t, err := time.Parse(time.RFC3339, createdFrom)
+		if err != nil {
+			return filter, errors.Wrap(err, "createdFrom")
+		}
+		filter.CreatedFrom = &t
+	}
+	if createdTo := c.QueryParam("createdTo"); createdTo != "" {
+		t, err := time.Parse(time.RFC3339, createdTo)
+		if err != nil {
+			return filter, errors.Wrap(err, "createdTo")
+		}
+		filter.CreatedTo = &t
 	}
+	return filter, nil
 }
@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/config"
+	"github.com/AleksK1NG/nats-streaming/internal/email"
+	"github.com/AleksK1NG/nats-streaming/internal/email/events"
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+type useCaseStub struct {
+	email.UseCase
+	bus       *events.Bus
+	exported  string
+	exportErr error
+}
+
+func (u *useCaseStub) WatchStatus(_ context.Context, filter models.StatusFilter, afterSequence uint64) (*events.Subscription, error) {
+	return u.bus.Subscribe(filter, afterSequence)
+}
+
+func (u *useCaseStub) Export(_ context.Context, _ *models.ExportQuery, w io.Writer) (int, error) {
+	if _, err := io.WriteString(w, u.exported); err != nil {
+		return 0, err
+	}
+	w.(http.Flusher).Flush()
+	return 1, u.exportErr
+}
+
+func newTestServer(emailUC email.UseCase) *httptest.Server {
+	cfg := &config.Config{Logger: config.Logger{Level: "error", Encoding: "console"}}
+	log := logger.NewApiLogger(cfg)
+	log.InitLogger()
+
+	e := echo.New()
+	h := NewEmailHandlers(e.Group("/email"), emailUC, log, cfg)
+	h.MapRoutes()
+	return httptest.NewServer(e)
+}
+
+func TestEventsResumeAfterLastEventID(t *testing.T) {
+	bus := events.NewBus(10)
+	for i := 1; i <= 3; i++ {
+		bus.Publish(&models.StatusEvent{Sequence: uint64(i), Type: models.EventTypeQueued})
+	}
+
+	srv := newTestServer(&useCaseStub{bus: bus})
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/email/events", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	req.Header.Set("Last-Event-ID", "1")
+
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if contentType := res.Header.Get(echo.HeaderContentType); contentType != "text/event-stream" {
+		t.Fatalf("content type = %q, want text/event-stream", contentType)
+	}
+
+	ids := make([]string, 0, 2)
+	scanner := bufio.NewScanner(res.Body)
+	for len(ids) < 2 && scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "id: ") {
+			ids = append(ids, strings.TrimPrefix(line, "id: "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner.Err: %v", err)
+	}
+	if strings.Join(ids, ",") != "2,3" {
+		t.Fatalf("ids = %v, want [2 3]", ids)
+	}
+}
+
+func TestWriteErrorEvent(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeErrorEvent(&buf, models.ErrSlowConsumer); err != nil {
+		t.Fatalf("writeErrorEvent: %v", err)
+	}
+
+	want := "retry: 1000\nevent: error\ndata: {\"status\":429,\"error\":\"Status events consumer is too slow\",\"err_causes\":\"Status events consumer is too slow\",\"retry_after\":1}\n\n"
+	if buf.String() != want {
+		t.Fatalf("event = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name      string
+		exportErr error
+		wantErr   bool
+	}{
+		{name: "complete"},
+		{name: "failed after file is partially sent", exportErr: errors.New("conn closed"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(&useCaseStub{exported: "emailID\n", exportErr: tt.exportErr})
+			defer srv.Close()
+
+			res, err := srv.Client().Get(srv.URL + "/email/export")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want %d", res.StatusCode, http.StatusOK)
+			}
+			body, err := io.ReadAll(res.Body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("read body error = %v, want error: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(body) != "emailID\n" {
+				t.Fatalf("body = %q, want %q", body, "emailID\n")
+			}
+		})
+	}
+}
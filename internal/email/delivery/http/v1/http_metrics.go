@@ -14,16 +14,12 @@ var (
 		Name: "http_email_error_incoming_message_total",
 		Help: "The total number of error incoming email HTTP requests",
 	})
-	createRequests = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "http_email_create_incoming_requests_total",
-		Help: "The total number of incoming create email HTTP requests",
+	exportRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_email_export_incoming_requests_total",
+		Help: "The total number of incoming export email HTTP requests",
 	})
-	getByIdRequests = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "http_email_get_by_id_incoming_requests_total",
-		Help: "The total number of incoming get by id email HTTP requests",
-	})
-	searchRequests = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "http_email_search_incoming_requests_total",
-		Help: "The total number of incoming search email HTTP requests",
+	eventsRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_email_events_incoming_requests_total",
+		Help: "The total number of incoming email events stream HTTP requests",
 	})
 )
@@ -1,8 +1,23 @@
 package v1
 
-// MapRoutes emails REST API routes
+// MapRoutes emails REST only routes, email routes having gRPC method are served by gateway, see mapGatewayRoutes
 func (h *emailHandlers) MapRoutes() {
+	h.group.GET("/export", h.Export())
+	h.group.GET("/events", h.Events())
+}
+
+// MapRoutes admin REST API routes
+func (h *adminHandlers) MapRoutes() {
+	h.group.GET("/workers", h.GetWorkers())
+	h.group.PUT("/workers", h.ScaleWorkers())
+}
+
+// MapRoutes webhooks REST API routes
+func (h *webhookHandlers) MapRoutes() {
 	h.group.POST("", h.Create())
-	h.group.GET("/:email_id", h.GetByID())
-	h.group.GET("/search", h.Search())
+	h.group.GET("", h.List())
+	h.group.GET("/:webhook_id", h.GetByID())
+	h.group.PUT("/:webhook_id", h.Update())
+	h.group.DELETE("/:webhook_id", h.Delete())
+	h.group.GET("/:webhook_id/deliveries", h.GetDeliveries())
 }
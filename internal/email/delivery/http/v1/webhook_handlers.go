@@ -0,0 +1,226 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AleksK1NG/nats-streaming/internal/email"
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	httpErrors "github.com/AleksK1NG/nats-streaming/pkg/http_errors"
+	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/opentracing/opentracing-go"
+	uuid "github.com/satori/go.uuid"
+)
+
+type webhookHandlers struct {
+	group     *echo.Group
+	webhookUC email.WebhookUseCase
+	log       logger.Logger
+	validate  *validator.Validate
+}
+
+// NewWebhookHandlers webhookHandlers constructor
+func NewWebhookHandlers(group *echo.Group, webhookUC email.WebhookUseCase, log logger.Logger, validate *validator.Validate) *webhookHandlers {
+	return &webhookHandlers{group: group, webhookUC: webhookUC, log: log, validate: validate}
+}
+
+// Create Create
+// @Tags Webhooks
+// @Summary Create webhook
+// @Description Create webhook subscription to email delivery events, secret is generated if not set and returned only in this response, url must be https url of public address, redirects are not followed
+// @Accept json
+// @Produce json
+// @Param body body models.Webhook true "url, event types and secret"
+// @Success 201 {object} models.Webhook
+// @Router /webhooks [post]
+func (h *webhookHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "webhookHandlers.Create")
+		defer span.Finish()
+
+		var webhook models.Webhook
+		if err := c.Bind(&webhook); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		if err := h.validate.StructCtx(ctx, &webhook); err != nil {
+			h.log.Errorf("validate.StructCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		created, err := h.webhookUC.Create(ctx, &webhook)
+		if err != nil {
+			h.log.Errorf("webhookUC.Create: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusCreated, created)
+	}
+}
+
+// List List
+// @Tags Webhooks
+// @Summary Get webhooks
+// @Description Get all webhook subscriptions
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.WebhooksList
+// @Router /webhooks [get]
+func (h *webhookHandlers) List() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "webhookHandlers.List")
+		defer span.Finish()
+
+		list, err := h.webhookUC.List(ctx)
+		if err != nil {
+			h.log.Errorf("webhookUC.List: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, list)
+	}
+}
+
+// GetByID GetByID
+// @Tags Webhooks
+// @Summary Get webhook by id
+// @Description Get webhook subscription by id
+// @Accept json
+// @Produce json
+// @Param webhook_id path string true "webhook_id"
+// @Success 200 {object} models.Webhook
+// @Router /webhooks/{webhook_id} [get]
+func (h *webhookHandlers) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "webhookHandlers.GetByID")
+		defer span.Finish()
+
+		webhookUUID, err := uuid.FromString(c.Param("webhook_id"))
+		if err != nil {
+			h.log.Errorf("uuid.FromString: %v", err)
+			return httpErrors.ErrorCtxResponse(c, domainErrors.NewFieldError("webhook_id", err))
+		}
+
+		webhook, err := h.webhookUC.GetByID(ctx, webhookUUID)
+		if err != nil {
+			h.log.Errorf("webhookUC.GetByID: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, webhook)
+	}
+}
+
+// Update Update
+// @Tags Webhooks
+// @Summary Update webhook
+// @Description Update webhook url, event types, secret and enabled state, enabling webhook resets its consecutive failures
+// @Accept json
+// @Produce json
+// @Param webhook_id path string true "webhook_id"
+// @Param body body models.UpdateWebhookReq true "webhook fields"
+// @Success 200 {object} models.Webhook
+// @Router /webhooks/{webhook_id} [put]
+func (h *webhookHandlers) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "webhookHandlers.Update")
+		defer span.Finish()
+
+		webhookUUID, err := uuid.FromString(c.Param("webhook_id"))
+		if err != nil {
+			h.log.Errorf("uuid.FromString: %v", err)
+			return httpErrors.ErrorCtxResponse(c, domainErrors.NewFieldError("webhook_id", err))
+		}
+
+		var req models.UpdateWebhookReq
+		if err := c.Bind(&req); err != nil {
+			h.log.Errorf("c.Bind: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		if err := h.validate.StructCtx(ctx, &req); err != nil {
+			h.log.Errorf("validate.StructCtx: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		webhook, err := h.webhookUC.Update(ctx, webhookUUID, &req)
+		if err != nil {
+			h.log.Errorf("webhookUC.Update: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, webhook)
+	}
+}
+
+// Delete Delete
+// @Tags Webhooks
+// @Summary Delete webhook
+// @Description Delete webhook subscription with its delivery log
+// @Accept json
+// @Produce json
+// @Param webhook_id path string true "webhook_id"
+// @Success 204
+// @Router /webhooks/{webhook_id} [delete]
+func (h *webhookHandlers) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "webhookHandlers.Delete")
+		defer span.Finish()
+
+		webhookUUID, err := uuid.FromString(c.Param("webhook_id"))
+		if err != nil {
+			h.log.Errorf("uuid.FromString: %v", err)
+			return httpErrors.ErrorCtxResponse(c, domainErrors.NewFieldError("webhook_id", err))
+		}
+
+		if err := h.webhookUC.Delete(ctx, webhookUUID); err != nil {
+			h.log.Errorf("webhookUC.Delete: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// GetDeliveries GetDeliveries
+// @Tags Webhooks
+// @Summary Get webhook deliveries
+// @Description Get last deliveries of webhook with result of last attempt, newest first
+// @Accept json
+// @Produce json
+// @Param webhook_id path string true "webhook_id"
+// @Param limit query int false "number of deliveries, 50 by default, at most 500"
+// @Success 200 {object} models.WebhookDeliveriesList
+// @Router /webhooks/{webhook_id}/deliveries [get]
+func (h *webhookHandlers) GetDeliveries() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		span, ctx := opentracing.StartSpanFromContext(c.Request().Context(), "webhookHandlers.GetDeliveries")
+		defer span.Finish()
+
+		webhookUUID, err := uuid.FromString(c.Param("webhook_id"))
+		if err != nil {
+			h.log.Errorf("uuid.FromString: %v", err)
+			return httpErrors.ErrorCtxResponse(c, domainErrors.NewFieldError("webhook_id", err))
+		}
+
+		var limit int
+		if limitParam := c.QueryParam("limit"); limitParam != "" {
+			if limit, err = strconv.Atoi(limitParam); err != nil {
+				h.log.Errorf("strconv.Atoi: %v", err)
+				return httpErrors.ErrorCtxResponse(c, httpErrors.NewBadRequestError(err.Error()))
+			}
+		}
+
+		deliveries, err := h.webhookUC.GetDeliveries(ctx, webhookUUID, limit)
+		if err != nil {
+			h.log.Errorf("webhookUC.GetDeliveries: %v", err)
+			return httpErrors.ErrorCtxResponse(c, err)
+		}
+
+		return c.JSON(http.StatusOK, deliveries)
+	}
+}
@@ -18,4 +18,24 @@ var (
 		Name: "nats_email_error_incoming_messages_total",
 		Help: "The total number of error email NATS messages",
 	})
+	retriedMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "nats_email_retried_messages_total",
+		Help: "The total number of email NATS messages republished for delayed retry",
+	})
+	deadLetterMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "nats_email_dead_letter_messages_total",
+		Help: "The total number of email NATS messages published to dead letter queue",
+	})
+	smtpSendErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "nats_email_smtp_send_errors_total",
+		Help: "The total number of failed email SMTP sends by error class and reply code",
+	}, []string{"class", "code"})
+	heldBackMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "nats_email_held_back_messages_total",
+		Help: "The total number of send email NATS messages left for redelivery while higher priority lanes have backlog",
+	}, []string{"priority"})
+	subscriptionWorkers = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nats_email_subscription_workers",
+		Help: "The current number of running email NATS subscription workers",
+	}, []string{"subject"})
 )
@@ -0,0 +1,117 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// channelsz nats streaming monitoring endpoint of channel with its subscriptions
+const channelszPath = "/streaming/channelsz"
+
+type channelz struct {
+	LastSeq       uint64          `json:"last_seq"`
+	Subscriptions []subscriptionz `json:"subscriptions"`
+}
+
+type subscriptionz struct {
+	QueueName    string `json:"queue_name"`
+	LastSent     uint64 `json:"last_sent"`
+	PendingCount int64  `json:"pending_count"`
+}
+
+// queueBacklog cached backlog of queue group of one channel
+type queueBacklog struct {
+	backlog   int64
+	fetchedAt time.Time
+}
+
+// streamingMonitor reads queue group backlog of channels from nats streaming monitoring endpoint,
+// backlog counts all replicas of queue group, so it includes messages in progress on other instances
+type streamingMonitor struct {
+	baseURL    string
+	httpClient *http.Client
+	ttl        time.Duration
+	mu         sync.Mutex
+	cache      map[string]queueBacklog
+}
+
+func newStreamingMonitor(baseURL string, ttl time.Duration) *streamingMonitor {
+	return &streamingMonitor{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: time.Second},
+		ttl:        ttl,
+		cache:      make(map[string]queueBacklog),
+	}
+}
+
+// QueueBacklog number of channel messages not acked by queue group: not sent yet and sent but not acked
+func (m *streamingMonitor) QueueBacklog(ctx context.Context, channel string, queueName string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cached, ok := m.cache[channel]; ok && time.Since(cached.fetchedAt) < m.ttl {
+		return cached.backlog, nil
+	}
+
+	ch, err := m.channel(ctx, channel)
+	if err != nil {
+		return 0, err
+	}
+
+	var lastSent uint64
+	var pending int64
+	subscribed := false
+	for _, sub := range ch.Subscriptions {
+		if sub.QueueName != queueName {
+			continue
+		}
+		subscribed = true
+		if sub.LastSent > lastSent {
+			lastSent = sub.LastSent
+		}
+		pending += sub.PendingCount
+	}
+
+	var backlog int64
+	if subscribed && ch.LastSeq > lastSent {
+		backlog = int64(ch.LastSeq - lastSent)
+	}
+	backlog += pending
+
+	m.cache[channel] = queueBacklog{backlog: backlog, fetchedAt: time.Now()}
+	return backlog, nil
+}
+
+func (m *streamingMonitor) channel(ctx context.Context, channel string) (*channelz, error) {
+	query := url.Values{"channel": {channel}, "subs": {"1"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.baseURL+channelszPath+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "http.NewRequestWithContext")
+	}
+
+	res, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "httpClient.Do")
+	}
+	defer res.Body.Close()
+
+	// channel is created on first publish or subscription
+	if res.StatusCode == http.StatusNotFound {
+		return &channelz{}, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("channelsz status: %d", res.StatusCode)
+	}
+
+	var ch channelz
+	if err := json.NewDecoder(res.Body).Decode(&ch); err != nil {
+		return nil, errors.Wrap(err, "json.Decode")
+	}
+	return &ch, nil
+}
@@ -0,0 +1,50 @@
+package nats
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStreamingMonitorQueueBacklog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != channelszPath || r.URL.Query().Get("subs") != "1" {
+			t.Errorf("request = %s, want %s with subscriptions", r.URL, channelszPath)
+		}
+		switch r.URL.Query().Get("channel") {
+		case "mail:send:high":
+			_, _ = w.Write([]byte(`{"last_seq":120,"subscriptions":[
+				{"queue_name":"dur:email_service","last_sent":100,"pending_count":3},
+				{"queue_name":"dur:email_service","last_sent":110,"pending_count":2},
+				{"queue_name":"dur:other","last_sent":0,"pending_count":7}
+			]}`))
+		case "mail:send:idle":
+			_, _ = w.Write([]byte(`{"last_seq":120,"subscriptions":[{"queue_name":"dur:email_service","last_sent":120,"pending_count":0}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		channel string
+		want    int64
+	}{
+		{channel: "mail:send:high", want: 15},
+		{channel: "mail:send:idle", want: 0},
+		{channel: "mail:send:unknown", want: 0},
+	}
+
+	m := newStreamingMonitor(srv.URL, time.Minute)
+	for _, tt := range tests {
+		got, err := m.QueueBacklog(context.Background(), tt.channel, "dur:email_service")
+		if err != nil {
+			t.Fatalf("QueueBacklog(%s): %v", tt.channel, err)
+		}
+		if got != tt.want {
+			t.Errorf("QueueBacklog(%s) = %d, want %d", tt.channel, got, tt.want)
+		}
+	}
+}
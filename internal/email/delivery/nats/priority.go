@@ -0,0 +1,71 @@
+package nats
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+)
+
+const gateCheckInterval = 50 * time.Millisecond
+
+// priorityLevels lower level is dispatched first
+var priorityLevels = map[string]int{
+	models.PriorityHigh:   0,
+	models.PriorityNormal: 1,
+	models.PriorityLow:    2,
+}
+
+// LaneBacklog returns number of not acked messages of send lane with given priority level
+type LaneBacklog func(ctx context.Context, level int) int64
+
+// priorityGate holds back lower priority lanes while higher priority lanes have backlog
+type priorityGate struct {
+	inProgress [3]int64
+	backlog    LaneBacklog
+}
+
+// Enter waits up to maxWait until higher priority lanes have no backlog, returns false if they still have,
+// then message must not be processed and is left not acked for redelivery.
+// Returned func must be called when message is processed.
+func (g *priorityGate) Enter(ctx context.Context, priority string, maxWait time.Duration) (func(), bool) {
+	level := priorityLevels[priority]
+	atomic.AddInt64(&g.inProgress[level], 1)
+	done := func() { atomic.AddInt64(&g.inProgress[level], -1) }
+
+	if !g.higherBacklog(ctx, level) {
+		return done, true
+	}
+
+	timer := time.NewTimer(maxWait)
+	defer timer.Stop()
+	ticker := time.NewTicker(gateCheckInterval)
+	defer ticker.Stop()
+
+	for g.higherBacklog(ctx, level) {
+		select {
+		case <-ctx.Done():
+			return done, false
+		case <-timer.C:
+			return done, false
+		case <-ticker.C:
+		}
+	}
+
+	return done, true
+}
+
+// InProgress number of messages of priority level handled by this instance
+func (g *priorityGate) InProgress(level int) int64 {
+	return atomic.LoadInt64(&g.inProgress[level])
+}
+
+func (g *priorityGate) higherBacklog(ctx context.Context, level int) bool {
+	for i := 0; i < level; i++ {
+		if g.backlog(ctx, i) > 0 {
+			return true
+		}
+	}
+	return false
+}
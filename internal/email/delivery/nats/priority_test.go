@@ -0,0 +1,43 @@
+package nats
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+)
+
+func TestPriorityGateEnter(t *testing.T) {
+	var highBacklog int64 = 1
+	g := &priorityGate{backlog: func(_ context.Context, level int) int64 {
+		if level == priorityLevels[models.PriorityHigh] {
+			return atomic.LoadInt64(&highBacklog)
+		}
+		return 0
+	}}
+
+	done, ok := g.Enter(context.Background(), models.PriorityHigh, time.Second)
+	if !ok {
+		t.Fatalf("high priority message is held back")
+	}
+	done()
+
+	done, ok = g.Enter(context.Background(), models.PriorityLow, 2*gateCheckInterval)
+	if ok {
+		t.Fatalf("low priority message is not held back while high lane has backlog")
+	}
+	done()
+
+	time.AfterFunc(2*gateCheckInterval, func() { atomic.StoreInt64(&highBacklog, 0) })
+	done, ok = g.Enter(context.Background(), models.PriorityLow, time.Second)
+	if !ok {
+		t.Fatalf("low priority message is held back after high lane backlog is processed")
+	}
+	done()
+
+	if inProgress := g.InProgress(priorityLevels[models.PriorityLow]); inProgress != 0 {
+		t.Fatalf("in progress = %d, want 0", inProgress)
+	}
+}
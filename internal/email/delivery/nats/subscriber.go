@@ -3,17 +3,21 @@ package nats
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/AleksK1NG/nats-streaming/config"
 	"github.com/AleksK1NG/nats-streaming/internal/email"
 	"github.com/AleksK1NG/nats-streaming/internal/models"
 	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/AleksK1NG/nats-streaming/pkg/smtp"
 	"github.com/avast/retry-go"
 	"github.com/go-playground/validator/v10"
 	"github.com/nats-io/stan.go"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -24,67 +28,249 @@ const (
 type emailSubscriber struct {
 	stanConn  stan.Conn
 	log       logger.Logger
+	cfg       *config.Config
 	emailUC   email.UseCase
 	validator *validator.Validate
+	gate      *priorityGate
+	monitor   *streamingMonitor
+	mu        sync.Mutex
+	pools     map[string]*workerPool
+}
+
+// WorkerHandler creates message handler of worker with given id
+type WorkerHandler func(workerID string) stan.MsgHandler
+
+// workerPool queue group subscriptions of one subject, each subscription is a worker
+type workerPool struct {
+	cfg     config.Subscription
+	handler WorkerHandler
+	workers []stan.Subscription
 }
 
 // NewEmailSubscriber email subscriber constructor
-func NewEmailSubscriber(stanConn stan.Conn, log logger.Logger, emailUC email.UseCase, validator *validator.Validate) *emailSubscriber {
-	return &emailSubscriber{stanConn: stanConn, log: log, emailUC: emailUC, validator: validator}
-}
-
-// Subscribe subscribe to subject and run workers with given callback for handling messages
-func (s *emailSubscriber) Subscribe(subject, qgroup string, workersNum int, cb stan.MsgHandler) {
-	s.log.Infof("Subscribing to Subject: %v, group: %v", subject, qgroup)
-	wg := &sync.WaitGroup{}
-
-	for i := 0; i <= workersNum; i++ {
-		wg.Add(1)
-		go s.runWorker(
-			wg,
-			i,
-			s.stanConn,
-			subject,
-			qgroup,
-			cb,
-			stan.SetManualAckMode(),
-			stan.AckWait(ackWait),
-			stan.DurableName(durableName),
-			stan.MaxInflight(maxInflight),
-			stan.DeliverAllAvailable(),
-		)
-	}
-	wg.Wait()
-}
-
-func (s *emailSubscriber) runWorker(
-	wg *sync.WaitGroup,
-	workerID int,
-	conn stan.Conn,
-	subject string,
-	qgroup string,
-	cb stan.MsgHandler,
-	opts ...stan.SubscriptionOption,
-) {
-	s.log.Infof("Subscribing worker: %v, subject: %v, qgroup: %v", workerID, subject, qgroup)
-	defer wg.Done()
-
-	_, err := conn.QueueSubscribe(subject, qgroup, cb, opts...)
+func NewEmailSubscriber(stanConn stan.Conn, log logger.Logger, cfg *config.Config, emailUC email.UseCase, validator *validator.Validate) *emailSubscriber {
+	s := &emailSubscriber{
+		stanConn:  stanConn,
+		log:       log,
+		cfg:       cfg,
+		emailUC:   emailUC,
+		validator: validator,
+		pools:     make(map[string]*workerPool),
+	}
+	s.gate = &priorityGate{backlog: s.laneBacklog}
+	if cfg.Nats.MonitoringURL != "" {
+		s.monitor = newStreamingMonitor(cfg.Nats.MonitoringURL, gateCheckInterval)
+	}
+	return s
+}
+
+// Subscribe subscribe to subject and run configured number of workers with given callback for handling messages,
+// messages are rate limited per subject if subscription RateLimit is set
+func (s *emailSubscriber) Subscribe(ctx context.Context, sub config.Subscription, handler WorkerHandler) error {
+	s.log.Infof("Subscribing to Subject: %v, group: %v, workers: %v", sub.Subject, sub.QueueGroup, sub.Workers)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pools[sub.Subject]; ok {
+		return errors.Errorf("subject %s already subscribed", sub.Subject)
+	}
+
+	if sub.RateLimit > 0 {
+		handler = rateLimited(ctx, rate.NewLimiter(rate.Limit(sub.RateLimit), sub.RateBurst), handler)
+	}
+
+	pool := &workerPool{cfg: sub, handler: handler, workers: make([]stan.Subscription, 0, sub.Workers)}
+	s.pools[sub.Subject] = pool
+
+	return s.scalePool(pool, sub.Workers)
+}
+
+// ScaleWorkers change number of running workers for subject
+func (s *emailSubscriber) ScaleWorkers(subject string, workersNum int) error {
+	if workersNum < 0 || workersNum > maxWorkersNum {
+		return errors.Wrapf(ErrInvalidWorkersNum, "workers: %d", workersNum)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pool, ok := s.pools[subject]
+	if !ok {
+		return errors.Wrapf(ErrSubjectNotFound, "subject: %s", subject)
+	}
+
+	return s.scalePool(pool, workersNum)
+}
+
+// Workers returns number of running workers per subject
+func (s *emailSubscriber) Workers() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workers := make(map[string]int, len(s.pools))
+	for subject, pool := range s.pools {
+		workers[subject] = len(pool.workers)
+	}
+	return workers
+}
+
+func (s *emailSubscriber) scalePool(pool *workerPool, workersNum int) error {
+	defer func() {
+		subscriptionWorkers.WithLabelValues(pool.cfg.Subject).Set(float64(len(pool.workers)))
+	}()
+
+	for len(pool.workers) < workersNum {
+		workerID := len(pool.workers)
+		sub, err := s.runWorker(workerID, pool)
+		if err != nil {
+			return errors.Wrapf(err, "runWorker: %d", workerID)
+		}
+		pool.workers = append(pool.workers, sub)
+	}
+
+	for len(pool.workers) > workersNum {
+		workerID := len(pool.workers) - 1
+		s.log.Infof("Stopping worker: %v, subject: %v", workerID, pool.cfg.Subject)
+		// Close instead of Unsubscribe keeps durable queue group state for remaining and future workers
+		if err := pool.workers[workerID].Close(); err != nil {
+			return errors.Wrapf(err, "subscription.Close: %d", workerID)
+		}
+		pool.workers = pool.workers[:workerID]
+	}
+
+	return nil
+}
+
+func (s *emailSubscriber) runWorker(workerID int, pool *workerPool) (stan.Subscription, error) {
+	s.log.Infof("Subscribing worker: %v, subject: %v, qgroup: %v", workerID, pool.cfg.Subject, pool.cfg.QueueGroup)
+
+	sub, err := s.stanConn.QueueSubscribe(
+		pool.cfg.Subject,
+		pool.cfg.QueueGroup,
+		pool.handler(fmt.Sprintf("%s/%s/%d", s.cfg.Nats.ClientID, pool.cfg.Subject, workerID)),
+		stan.SetManualAckMode(),
+		stan.AckWait(pool.cfg.AckWait*time.Second),
+		stan.DurableName(pool.cfg.DurableName),
+		stan.MaxInflight(pool.cfg.MaxInflight),
+		stan.DeliverAllAvailable(),
+	)
 	if err != nil {
 		s.log.Errorf("WorkerID: %v, QueueSubscribe: %v", workerID, err)
-		if err := conn.Close(); err != nil {
-			s.log.Errorf("WorkerID: %v, conn.Close error: %v", workerID, err)
+		return nil, errors.Wrap(err, "QueueSubscribe")
+	}
+
+	return sub, nil
+}
+
+// rateLimited limiter is shared by all workers of handler
+func rateLimited(ctx context.Context, limiter *rate.Limiter, handler WorkerHandler) WorkerHandler {
+	return func(workerID string) stan.MsgHandler {
+		cb := handler(workerID)
+		return func(msg *stan.Msg) {
+			// message is not acked on cancel and will be redelivered
+			if err := limiter.Wait(ctx); err != nil {
+				return
+			}
+			cb(msg)
 		}
 	}
 }
 
 // Run start subscribers
 func (s *emailSubscriber) Run(ctx context.Context) {
-	go s.Subscribe(createEmailSubject, emailGroupName, createEmailWorkers, s.processCreateEmail(ctx))
-	go s.Subscribe(sendEmailSubject, emailGroupName, sendEmailWorkers, s.processSendEmail(ctx))
+	if err := s.Subscribe(ctx, s.cfg.Nats.CreateEmail, s.processCreateEmail(ctx)); err != nil {
+		s.log.Errorf("Subscribe: %v", err)
+	}
+
+	for _, lane := range s.sendLanes() {
+		if err := s.Subscribe(ctx, lane.sub, s.processSendEmail(ctx, lane.priority, lane.sub.AckWait*time.Second/2)); err != nil {
+			s.log.Errorf("Subscribe: %v", err)
+		}
+	}
+
+	if err := s.subscribeStatusEvents(); err != nil {
+		s.log.Errorf("subscribeStatusEvents: %v", err)
+	}
+
+	go s.runRetryScheduler(ctx)
+}
+
+type sendLane struct {
+	sub      config.Subscription
+	priority string
+}
+
+// sendLanes send email subscriptions indexed by priority level
+func (s *emailSubscriber) sendLanes() []sendLane {
+	return []sendLane{
+		{sub: s.cfg.Nats.SendEmailHigh, priority: models.PriorityHigh},
+		{sub: s.cfg.Nats.SendEmail, priority: models.PriorityNormal},
+		{sub: s.cfg.Nats.SendEmailLow, priority: models.PriorityLow},
+	}
+}
+
+// laneBacklog backlog of queue group of all instances from nats streaming monitoring endpoint,
+// without monitoring only messages of this instance are counted: buffered by its workers and in progress
+func (s *emailSubscriber) laneBacklog(ctx context.Context, level int) int64 {
+	sub := s.sendLanes()[level].sub
+	if s.monitor != nil {
+		backlog, err := s.monitor.QueueBacklog(ctx, sub.Subject, queueName(sub))
+		if err == nil {
+			return backlog
+		}
+		s.log.Warnf("monitor.QueueBacklog: %v", err)
+	}
+
+	backlog := s.gate.InProgress(level)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pool, ok := s.pools[sub.Subject]; ok {
+		for _, worker := range pool.workers {
+			if pending, _, err := worker.Pending(); err == nil {
+				backlog += int64(pending)
+			}
+		}
+	}
+	return backlog
 }
 
-func (s *emailSubscriber) processCreateEmail(ctx context.Context) stan.MsgHandler {
+// queueName queue name of durable queue subscription as reported by monitoring endpoint
+func queueName(sub config.Subscription) string {
+	if sub.DurableName == "" {
+		return sub.QueueGroup
+	}
+	return sub.DurableName + ":" + sub.QueueGroup
+}
+
+// subscribeStatusEvents every instance receives all status events, subscription starts at replay window
+// so events buffered for watch resumption survive restart, message sequence is used as event sequence
+func (s *emailSubscriber) subscribeStatusEvents() error {
+	s.log.Infof("Subscribing to status events Subject: %v", s.cfg.Nats.StatusEvents.Subject)
+
+	_, err := s.stanConn.Subscribe(s.cfg.Nats.StatusEvents.Subject, func(msg *stan.Msg) {
+		var event models.StatusEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			s.log.Errorf("json.Unmarshal : %v", err)
+			return
+		}
+		event.Sequence = msg.Sequence
+		s.emailUC.DispatchStatusEvent(&event)
+	}, stan.StartAtTimeDelta(s.cfg.Nats.StatusEvents.ReplayWindow*time.Second))
+	if err != nil {
+		return errors.Wrap(err, "Subscribe")
+	}
+
+	return nil
+}
+
+func (s *emailSubscriber) processCreateEmail(ctx context.Context) WorkerHandler {
+	return func(workerID string) stan.MsgHandler {
+		return s.createEmailHandler(ctx)
+	}
+}
+
+func (s *emailSubscriber) createEmailHandler(ctx context.Context) stan.MsgHandler {
 	return func(msg *stan.Msg) {
 		span, ctx := opentracing.StartSpanFromContext(ctx, "emailSubscriber.processCreateEmail")
 		defer span.Finish()
@@ -110,7 +296,7 @@ func (s *emailSubscriber) processCreateEmail(ctx context.Context) stan.MsgHandle
 			s.log.Errorf("emailUC.Create : %v", err)
 
 			if msg.Redelivered && msg.RedeliveryCount > maxRedeliveryCount {
-				if err := s.publishErrorMessage(ctx, msg, err); err != nil {
+				if err := s.publishErrorMessage(ctx, msg, err, nil); err != nil {
 					s.log.Errorf("publishErrorMessage : %v", err)
 					return
 				}
@@ -129,7 +315,15 @@ func (s *emailSubscriber) processCreateEmail(ctx context.Context) stan.MsgHandle
 	}
 }
 
-func (s *emailSubscriber) processSendEmail(ctx context.Context) stan.MsgHandler {
+// processSendEmail handle send email messages of priority lane, lower priority lanes wait up to maxWait
+// while higher priority lanes have backlog and then leave message for redelivery, so it is not sent before them
+func (s *emailSubscriber) processSendEmail(ctx context.Context, priority string, maxWait time.Duration) WorkerHandler {
+	return func(workerID string) stan.MsgHandler {
+		return s.sendEmailHandler(ctx, workerID, priority, maxWait)
+	}
+}
+
+func (s *emailSubscriber) sendEmailHandler(ctx context.Context, workerID string, priority string, maxWait time.Duration) stan.MsgHandler {
 	return func(msg *stan.Msg) {
 		span, ctx := opentracing.StartSpanFromContext(ctx, "emailSubscriber.processSendEmail")
 		defer span.Finish()
@@ -144,26 +338,20 @@ func (s *emailSubscriber) processSendEmail(ctx context.Context) stan.MsgHandler
 			return
 		}
 
-		if err := retry.Do(func() error {
-			return s.emailUC.SendEmail(ctx, &m)
-		},
-			retry.Attempts(retryAttempts),
-			retry.Delay(retryDelay),
-			retry.Context(ctx),
-		); err != nil {
+		done, ok := s.gate.Enter(ctx, priority, maxWait)
+		defer done()
+		if !ok {
+			// message is not acked and is redelivered after ack wait
+			heldBackMessages.WithLabelValues(priority).Inc()
+			return
+		}
+
+		if err := s.emailUC.SendEmail(ctx, &m, workerID); err != nil {
 			errorSubscribeMessages.Inc()
+			sendErr := smtp.AsSendError(err)
+			smtpSendErrors.WithLabelValues(sendErr.Class, sendErr.CodeLabel()).Inc()
 			s.log.Errorf("emailUC.SendEmail : %v", err)
-
-			if msg.Redelivered && msg.RedeliveryCount > maxRedeliveryCount {
-				if err := s.publishErrorMessage(ctx, msg, err); err != nil {
-					s.log.Errorf("publishErrorMessage : %v", err)
-					return
-				}
-				if err := msg.Ack(); err != nil {
-					s.log.Errorf("msg.Ack: %v", err)
-					return
-				}
-			}
+			s.handleSendError(ctx, msg, &m, err)
 			return
 		}
 
@@ -174,19 +362,65 @@ func (s *emailSubscriber) processSendEmail(ctx context.Context) stan.MsgHandler
 	}
 }
 
-func (s *emailSubscriber) publishErrorMessage(ctx context.Context, msg *stan.Msg, err error) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "emailSubscriber.publishErrorMessage")
+// handleSendError schedule delayed retry of failed email and ack message, permanently failed emails are
+// published to dead letter queue, if retry can't be scheduled message is left for redelivery after ack wait
+func (s *emailSubscriber) handleSendError(ctx context.Context, msg *stan.Msg, m *models.Email, sendErr error) {
+	err := s.emailUC.ScheduleRetry(ctx, m, sendErr)
+	if err != nil && !errors.Is(err, email.ErrDeliveryFailed) {
+		s.log.Errorf("emailUC.ScheduleRetry : %v", err)
+		if !msg.Redelivered || msg.RedeliveryCount <= maxRedeliveryCount {
+			return
+		}
+	}
+
+	if err != nil {
+		if err := s.publishErrorMessage(ctx, msg, err, smtp.AsSendError(sendErr).DeliveryError()); err != nil {
+			s.log.Errorf("publishErrorMessage : %v", err)
+			return
+		}
+	}
+
+	if err := msg.Ack(); err != nil {
+		s.log.Errorf("msg.Ack: %v", err)
+	}
+}
+
+// runRetryScheduler periodically publish emails with due send attempt
+func (s *emailSubscriber) runRetryScheduler(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.MailService.Retry.PollInterval * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			published, err := s.emailUC.PublishDueRetries(ctx)
+			if err != nil {
+				s.log.Errorf("emailUC.PublishDueRetries: %v", err)
+			}
+			if published > 0 {
+				retriedMessages.Add(float64(published))
+				s.log.Infof("published due retries: %d", published)
+			}
+		}
+	}
+}
+
+func (s *emailSubscriber) publishErrorMessage(ctx context.Context, msg *stan.Msg, err error, deliveryErr *models.DeliveryError) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailSubscriber.publishErrorMessage")
 	defer span.Finish()
 
 	s.log.Infof("publish dead letter queue message: %v", msg)
 
 	errMsg := &models.EmailErrorMsg{
-		Subject:   msg.Subject,
-		Sequence:  msg.Sequence,
-		Data:      msg.Data,
-		Timestamp: msg.Timestamp,
-		Error:     err.Error(),
-		Time:      time.Now().UTC(),
+		Subject:       msg.Subject,
+		Sequence:      msg.Sequence,
+		Data:          msg.Data,
+		Timestamp:     msg.Timestamp,
+		Error:         err.Error(),
+		DeliveryError: deliveryErr,
+		Time:          time.Now().UTC(),
 	}
 
 	errMsgBytes, err := json.Marshal(&errMsg)
@@ -194,5 +428,14 @@ func (s *emailSubscriber) publishErrorMessage(ctx context.Context, msg *stan.Msg
 		return errors.Wrap(err, "json.Marshal")
 	}
 
-	return s.stanConn.Publish(deadLetterQueueSubject, errMsgBytes)
+	if err := s.stanConn.Publish(deadLetterQueueSubject, errMsgBytes); err != nil {
+		return errors.Wrap(err, "stanConn.Publish")
+	}
+	deadLetterMessages.Inc()
+
+	if err := s.emailUC.CreateDeadLetter(ctx, errMsg); err != nil {
+		return errors.Wrap(err, "emailUC.CreateDeadLetter")
+	}
+
+	return nil
 }
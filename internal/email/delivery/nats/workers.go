@@ -0,0 +1,14 @@
+package nats
+
+import "github.com/pkg/errors"
+
+var (
+	ErrSubjectNotFound   = errors.New("Subject not found")
+	ErrInvalidWorkersNum = errors.New("Invalid workers number")
+)
+
+// WorkersScaler scale subscription workers at runtime interface
+type WorkersScaler interface {
+	Workers() map[string]int
+	ScaleWorkers(subject string, workersNum int) error
+}
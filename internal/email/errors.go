@@ -0,0 +1,7 @@
+package email
+
+import "github.com/pkg/errors"
+
+var (
+	ErrDeliveryFailed = errors.New("Email delivery failed")
+)
@@ -0,0 +1,138 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	"github.com/pkg/errors"
+)
+
+// subscriptionBufferSize live events buffered per subscription before it is closed as slow consumer
+const subscriptionBufferSize = 256
+
+// Bus in process status events fan out with ring buffer of last events for resumption
+type Bus struct {
+	mu            sync.Mutex
+	buffer        []*models.StatusEvent
+	start         int
+	size          int
+	lastSequence  uint64
+	subscriptions map[*Subscription]struct{}
+}
+
+// NewBus status events bus constructor, bufferSize last events are kept for resumption
+func NewBus(bufferSize int) *Bus {
+	return &Bus{
+		buffer:        make([]*models.StatusEvent, bufferSize),
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscription status events subscription, C is closed when subscription is closed
+type Subscription struct {
+	C      <-chan *models.StatusEvent
+	ch     chan *models.StatusEvent
+	filter models.StatusFilter
+	after  uint64
+	bus    *Bus
+	err    error
+	closed bool
+}
+
+// Err returns reason of subscription closed by bus
+func (s *Subscription) Err() error {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	return s.err
+}
+
+// Close close subscription
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.unsubscribe(s, nil)
+}
+
+// Publish buffer event and deliver it to matching subscriptions, events with already seen sequence are ignored
+func (b *Bus) Publish(event *models.StatusEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if event.Sequence <= b.lastSequence {
+		return
+	}
+	b.lastSequence = event.Sequence
+
+	if len(b.buffer) > 0 {
+		if b.size < len(b.buffer) {
+			b.buffer[(b.start+b.size)%len(b.buffer)] = event
+			b.size++
+		} else {
+			b.buffer[b.start] = event
+			b.start = (b.start + 1) % len(b.buffer)
+		}
+	}
+
+	for s := range b.subscriptions {
+		b.deliver(s, event)
+	}
+}
+
+// LastSequence returns sequence of last published event
+func (b *Bus) LastSequence() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.lastSequence
+}
+
+// Subscribe subscribe to events matching filter, if afterSequence is not zero
+// buffered events with greater sequence are replayed first, subscription channel is sized
+// to hold whole replay so only live events count against slow consumer buffer
+func (b *Bus) Subscribe(filter models.StatusFilter, afterSequence uint64) (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	replay := make([]*models.StatusEvent, 0)
+	if afterSequence > 0 && afterSequence < b.lastSequence {
+		if b.size == 0 || b.buffer[b.start].Sequence > afterSequence+1 {
+			return nil, errors.Wrapf(models.ErrResumeExpired, "sequence: %d", afterSequence)
+		}
+		for i := 0; i < b.size; i++ {
+			event := b.buffer[(b.start+i)%len(b.buffer)]
+			if event.Sequence > afterSequence && filter.Match(event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	ch := make(chan *models.StatusEvent, subscriptionBufferSize+len(replay))
+	s := &Subscription{C: ch, ch: ch, filter: filter, after: afterSequence, bus: b}
+	for _, event := range replay {
+		b.deliver(s, event)
+	}
+
+	b.subscriptions[s] = struct{}{}
+	return s, nil
+}
+
+// deliver send event to subscription without blocking publisher, slow subscription is closed
+func (b *Bus) deliver(s *Subscription, event *models.StatusEvent) {
+	if s.closed || event.Sequence <= s.after || !s.filter.Match(event) {
+		return
+	}
+	select {
+	case s.ch <- event:
+	default:
+		b.unsubscribe(s, models.ErrSlowConsumer)
+	}
+}
+
+func (b *Bus) unsubscribe(s *Subscription, err error) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.err = err
+	delete(b.subscriptions, s)
+	close(s.ch)
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+)
+
+func TestBusSubscribeReplay(t *testing.T) {
+	const published = 1000
+
+	bus := NewBus(published)
+	for i := 1; i <= published; i++ {
+		bus.Publish(&models.StatusEvent{Sequence: uint64(i), Type: models.EventTypeQueued})
+	}
+
+	sub, err := bus.Subscribe(models.StatusFilter{}, 1)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer sub.Close()
+
+	bus.Publish(&models.StatusEvent{Sequence: published + 1, Type: models.EventTypeQueued})
+
+	for want := uint64(2); want <= published+1; want++ {
+		event, ok := <-sub.C
+		if !ok {
+			t.Fatalf("subscription closed at sequence %d: %v", want, sub.Err())
+		}
+		if event.Sequence != want {
+			t.Fatalf("sequence = %d, want %d", event.Sequence, want)
+		}
+	}
+}
+
+func TestBusSubscribeResumeExpired(t *testing.T) {
+	bus := NewBus(2)
+	for i := 1; i <= 5; i++ {
+		bus.Publish(&models.StatusEvent{Sequence: uint64(i), Type: models.EventTypeQueued})
+	}
+
+	if _, err := bus.Subscribe(models.StatusFilter{}, 1); !errors.Is(err, models.ErrResumeExpired) {
+		t.Fatalf("Subscribe error = %v, want %v", err, models.ErrResumeExpired)
+	}
+	if _, err := bus.Subscribe(models.StatusFilter{}, 3); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+}
+
+func TestBusSlowConsumer(t *testing.T) {
+	bus := NewBus(0)
+	sub, err := bus.Subscribe(models.StatusFilter{}, 0)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	for i := 1; i <= subscriptionBufferSize+1; i++ {
+		bus.Publish(&models.StatusEvent{Sequence: uint64(i), Type: models.EventTypeQueued})
+	}
+
+	if err := sub.Err(); !errors.Is(err, models.ErrSlowConsumer) {
+		t.Fatalf("Err = %v, want %v", err, models.ErrSlowConsumer)
+	}
+}
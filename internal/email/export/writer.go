@@ -0,0 +1,147 @@
+package export
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	"github.com/pkg/errors"
+)
+
+var csvHeader = []string{
+	"emailID",
+	"tenantID",
+	"from",
+	"to",
+	"subject",
+	"message",
+	"priority",
+	"language",
+	"status",
+	"attempts",
+	"lastErrorClass",
+	"lastError",
+	"createdAt",
+	"updatedAt",
+}
+
+// flusher output which buffers written data, e.g. http response
+type flusher interface {
+	Flush()
+}
+
+// Writer writes exported emails as csv or json lines, optionally gzip compressed
+type Writer struct {
+	out   io.Writer
+	gz    *gzip.Writer
+	csv   *csv.Writer
+	enc   *json.Encoder
+	count int
+}
+
+// NewWriter export writer constructor, csv header is written before first email
+func NewWriter(out io.Writer, format string, compress bool) (*Writer, error) {
+	w := &Writer{out: out}
+
+	dst := out
+	if compress {
+		w.gz = gzip.NewWriter(out)
+		dst = w.gz
+	}
+
+	switch format {
+	case models.ExportFormatCSV:
+		w.csv = csv.NewWriter(dst)
+		if err := w.csv.Write(csvHeader); err != nil {
+			return nil, errors.Wrap(err, "csv.Write")
+		}
+	case models.ExportFormatJSONL:
+		w.enc = json.NewEncoder(dst)
+	default:
+		return nil, errors.Wrapf(models.ErrInvalidExportFormat, "unsupported format: %s", format)
+	}
+
+	return w, nil
+}
+
+// Write write batch of emails
+func (w *Writer) Write(batch []*models.Email) error {
+	for _, m := range batch {
+		if w.csv != nil {
+			if err := w.csv.Write(csvRecord(m)); err != nil {
+				return errors.Wrap(err, "csv.Write")
+			}
+		} else if err := w.enc.Encode(m); err != nil {
+			return errors.Wrap(err, "enc.Encode")
+		}
+		w.count++
+	}
+	return nil
+}
+
+// Flush flush buffered emails to output, so client receives them before next batch is fetched
+func (w *Writer) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return errors.Wrap(err, "csv.Flush")
+		}
+	}
+	if w.gz != nil {
+		if err := w.gz.Flush(); err != nil {
+			return errors.Wrap(err, "gz.Flush")
+		}
+	}
+	if f, ok := w.out.(flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// Close flush buffered emails and finish gzip stream, output is not closed
+func (w *Writer) Close() error {
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if w.gz != nil {
+		if err := w.gz.Close(); err != nil {
+			return errors.Wrap(err, "gz.Close")
+		}
+		if f, ok := w.out.(flusher); ok {
+			f.Flush()
+		}
+	}
+	return nil
+}
+
+// Count returns number of written emails
+func (w *Writer) Count() int {
+	return w.count
+}
+
+func csvRecord(m *models.Email) []string {
+	var lastErrorClass, lastError string
+	if m.LastError != nil {
+		lastErrorClass, lastError = m.LastError.Class, m.LastError.Message
+	}
+	return []string{
+		m.EmailID.String(),
+		m.TenantID,
+		m.From,
+		m.To,
+		m.Subject,
+		m.Message,
+		m.GetPriority(),
+		m.GetLanguage(),
+		m.Status,
+		strconv.Itoa(m.Attempts),
+		lastErrorClass,
+		lastError,
+		m.CreatedAt.UTC().Format(time.RFC3339),
+		m.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
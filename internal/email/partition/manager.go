@@ -0,0 +1,114 @@
+package partition
+
+import (
+	"context"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/config"
+	"github.com/AleksK1NG/nats-streaming/internal/email"
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+type partitionManager struct {
+	log           logger.Logger
+	cfg           *config.Config
+	partitionRepo email.PartitionRepository
+}
+
+// NewPartitionManager emails partitions manager constructor
+func NewPartitionManager(log logger.Logger, cfg *config.Config, partitionRepo email.PartitionRepository) *partitionManager {
+	return &partitionManager{log: log, cfg: cfg, partitionRepo: partitionRepo}
+}
+
+// Run maintain emails partitions on start and every configured interval until context is done
+func (m *partitionManager) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.Partitions.Interval * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := m.maintain(ctx); err != nil {
+			partitionErrors.Inc()
+			m.log.Errorf("partitionManager.maintain: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// maintain create partitions for current and premade months and detach expired ones,
+// only one replica maintains partitions at a time
+func (m *partitionManager) maintain(ctx context.Context) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "partitionManager.maintain")
+	defer span.Finish()
+
+	unlock, acquired, err := m.partitionRepo.TryLock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "partitionRepo.TryLock")
+	}
+	if !acquired {
+		return nil
+	}
+	defer unlock()
+
+	partitions, err := m.partitionRepo.List(ctx)
+	if err != nil {
+		return errors.Wrap(err, "partitionRepo.List")
+	}
+	attached := make(map[string]bool, len(partitions))
+	for _, p := range partitions {
+		attached[p.Name] = true
+	}
+
+	now := time.Now().UTC()
+	for i := 0; i <= m.cfg.Partitions.PremakeMonths; i++ {
+		p := models.NewEmailsPartition(now.AddDate(0, i, 1-now.Day()))
+		if attached[p.Name] {
+			continue
+		}
+		if err := m.partitionRepo.Create(ctx, p); err != nil {
+			return errors.Wrapf(err, "partitionRepo.Create %s", p.Name)
+		}
+		createdPartitions.Inc()
+		attached[p.Name] = true
+		m.log.Infof("created emails partition: %s", p.Name)
+	}
+
+	if m.cfg.Partitions.RetainMonths > 0 {
+		retainFrom := models.NewEmailsPartition(now.AddDate(0, -m.cfg.Partitions.RetainMonths, 1-now.Day())).From
+		for _, p := range partitions {
+			if p.To.After(retainFrom) {
+				continue
+			}
+			if err := m.detach(ctx, p); err != nil {
+				return err
+			}
+			delete(attached, p.Name)
+		}
+	}
+
+	attachedPartitions.Set(float64(len(attached)))
+	return nil
+}
+
+func (m *partitionManager) detach(ctx context.Context, p *models.EmailsPartition) error {
+	if err := m.partitionRepo.Detach(ctx, p); err != nil {
+		return errors.Wrapf(err, "partitionRepo.Detach %s", p.Name)
+	}
+	detachedPartitions.Inc()
+	m.log.Infof("detached emails partition: %s", p.Name)
+
+	if m.cfg.Partitions.DropDetached {
+		if err := m.partitionRepo.Drop(ctx, p); err != nil {
+			return errors.Wrapf(err, "partitionRepo.Drop %s", p.Name)
+		}
+		m.log.Infof("dropped emails partition: %s", p.Name)
+	}
+	return nil
+}
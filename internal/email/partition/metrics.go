@@ -0,0 +1,25 @@
+package partition
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	createdPartitions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "emails_partitions_created_total",
+		Help: "The total number of created emails table partitions",
+	})
+	detachedPartitions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "emails_partitions_detached_total",
+		Help: "The total number of detached emails table partitions",
+	})
+	partitionErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "emails_partitions_errors_total",
+		Help: "The total number of failed emails partitions maintenance runs",
+	})
+	attachedPartitions = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "emails_partitions_attached",
+		Help: "The current number of attached monthly emails table partitions",
+	})
+)
@@ -2,22 +2,61 @@ package email
 
 import (
 	"context"
+	"time"
 
 	"github.com/AleksK1NG/nats-streaming/internal/models"
-	"github.com/AleksK1NG/nats-streaming/pkg/utils"
 	uuid "github.com/satori/go.uuid"
 )
 
 // PGRepository Email postgresql repository interface
 type PGRepository interface {
 	Create(ctx context.Context, email *models.Email) (*models.Email, error)
+	CreateBatch(ctx context.Context, emails []*models.Email) ([]*models.Email, error)
 	GetByID(ctx context.Context, emailID uuid.UUID) (*models.Email, error)
-	Search(ctx context.Context, search string, pagination *utils.Pagination) (*models.EmailsList, error)
+	Search(ctx context.Context, query *models.SearchQuery) (*models.EmailsList, error)
+	Export(ctx context.Context, query *models.SearchQuery, fetchSize int, fn func(batch []*models.Email) error) error
+	Update(ctx context.Context, emailID uuid.UUID, req *models.UpdateEmailReq, sendingLease time.Duration) (*models.Email, error)
+	ClaimForSending(ctx context.Context, emailID uuid.UUID, sendingLease time.Duration) (*models.Email, error)
+	SetSent(ctx context.Context, emailID uuid.UUID, attempts int) (*models.Email, error)
+	SetRetrying(ctx context.Context, emailID uuid.UUID, attempts int, nextAttemptAt time.Time, lastError *models.DeliveryError) (*models.Email, error)
+	SetFailed(ctx context.Context, emailID uuid.UUID, attempts int, lastError *models.DeliveryError) (*models.Email, error)
+	ClaimDueRetries(ctx context.Context, limit int) ([]*models.Email, error)
+	ReleaseRetries(ctx context.Context, claimed []*models.Email, nextAttemptAt time.Time) ([]*models.Email, error)
+	CreateDeliveryAttempt(ctx context.Context, attempt *models.DeliveryAttempt) error
+	GetDeliveryAttempts(ctx context.Context, emailID uuid.UUID) ([]*models.DeliveryAttempt, error)
+	GetExpired(ctx context.Context, status string, tenantID string, before time.Time, limit int) ([]*models.Email, error)
+	DeleteByIDs(ctx context.Context, emailIDs []uuid.UUID) (int64, error)
+	CreateDeadLetter(ctx context.Context, deadLetter *models.DeadLetter) error
+	Erase(ctx context.Context, req *models.ErasureReq) (*models.ErasureReport, error)
+}
+
+// PartitionRepository emails table partitions repository interface
+type PartitionRepository interface {
+	TryLock(ctx context.Context) (func(), bool, error)
+	List(ctx context.Context) ([]*models.EmailsPartition, error)
+	Create(ctx context.Context, partition *models.EmailsPartition) error
+	Detach(ctx context.Context, partition *models.EmailsPartition) error
+	Drop(ctx context.Context, partition *models.EmailsPartition) error
+}
+
+// WebhookRepository webhooks postgresql repository interface
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error)
+	GetByID(ctx context.Context, webhookID uuid.UUID) (*models.Webhook, error)
+	List(ctx context.Context) ([]*models.Webhook, error)
+	Update(ctx context.Context, webhookID uuid.UUID, req *models.UpdateWebhookReq) (*models.Webhook, error)
+	Delete(ctx context.Context, webhookID uuid.UUID) error
+	EnqueueDeliveries(ctx context.Context, event *models.StatusEvent, payload []byte) (int64, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	SetDelivered(ctx context.Context, deliveryID uuid.UUID, attempts int, statusCode int) error
+	SetFailed(ctx context.Context, deliveryID uuid.UUID, attempts int, statusCode int, lastError string, nextAttemptAt *time.Time, disableAfter int) (bool, error)
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*models.WebhookDelivery, error)
 }
 
 // RedisRepository redis email repository interface
 type RedisRepository interface {
 	SetEmail(ctx context.Context, email *models.Email) error
+	SetEmailNX(ctx context.Context, email *models.Email) error
 	GetEmailByID(ctx context.Context, emailID uuid.UUID) (*models.Email, error)
 	DeleteEmail(ctx context.Context, emailID uuid.UUID) error
 }
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+// partitionsLockID advisory lock key shared by all replicas maintaining emails partitions
+const partitionsLockID = 7318604125
+
+// partitionBoundLayout partition bound timestamp literal
+const partitionBoundLayout = "2006-01-02 15:04:05Z07:00"
+
+type partitionPGRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPartitionPGRepository Emails partitions postgresql repository constructor
+func NewPartitionPGRepository(db *pgxpool.Pool) *partitionPGRepository {
+	return &partitionPGRepository{db: db}
+}
+
+// TryLock try to acquire partitions maintenance lock, returns unlock func if lock is acquired
+func (p *partitionPGRepository) TryLock(ctx context.Context) (func(), bool, error) {
+	conn, err := p.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "db.Acquire")
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, tryPartitionsLockQuery, partitionsLockID).Scan(&acquired); err != nil {
+		conn.Release()
+		return nil, false, errors.Wrap(err, "QueryRow")
+	}
+	if !acquired {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		defer conn.Release()
+		if _, err := conn.Exec(context.Background(), partitionsUnlockQuery, partitionsLockID); err != nil {
+			// lock is released with session, connection must not return to pool holding it
+			conn.Conn().Close(context.Background())
+		}
+	}
+	return unlock, true, nil
+}
+
+// List get attached monthly emails partitions ordered by month
+func (p *partitionPGRepository) List(ctx context.Context) ([]*models.EmailsPartition, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "partitionPGRepository.List")
+	defer span.Finish()
+
+	rows, err := p.db.Query(ctx, listPartitionsQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.Query")
+	}
+	defer rows.Close()
+
+	partitions := make([]*models.EmailsPartition, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		partition, err := models.ParseEmailsPartition(name)
+		if err != nil {
+			return nil, errors.Wrap(err, "models.ParseEmailsPartition")
+		}
+		partitions = append(partitions, partition)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows.Err")
+	}
+
+	return partitions, nil
+}
+
+// Create create partition if not exists, emails already stored in default partition for partition range
+// are moved to created partition in the same transaction, otherwise partition could not be attached
+func (p *partitionPGRepository) Create(ctx context.Context, partition *models.EmailsPartition) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "partitionPGRepository.Create")
+	defer span.Finish()
+
+	name := pgx.Identifier{partition.Name}.Sanitize()
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "db.Begin")
+	}
+	defer tx.Rollback(ctx) // nolint: errcheck
+
+	var exists bool
+	if err := tx.QueryRow(ctx, partitionExistsQuery, name).Scan(&exists); err != nil {
+		return errors.Wrap(err, "tx.QueryRow")
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(createPartitionTableQuery, name)); err != nil {
+		return errors.Wrap(err, "tx.Exec create")
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf(movePartitionRowsQuery, name), partition.From, partition.To); err != nil {
+		return errors.Wrap(err, "tx.Exec move")
+	}
+	query := fmt.Sprintf(
+		attachPartitionQuery,
+		name,
+		partition.From.UTC().Format(partitionBoundLayout),
+		partition.To.UTC().Format(partitionBoundLayout),
+	)
+	if _, err := tx.Exec(ctx, query); err != nil {
+		return errors.Wrap(err, "tx.Exec attach")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "tx.Commit")
+	}
+	return nil
+}
+
+// Detach detach partition from emails table, detached partition rows are no longer visible to queries
+func (p *partitionPGRepository) Detach(ctx context.Context, partition *models.EmailsPartition) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "partitionPGRepository.Detach")
+	defer span.Finish()
+
+	if _, err := p.db.Exec(ctx, fmt.Sprintf(detachPartitionQuery, pgx.Identifier{partition.Name}.Sanitize())); err != nil {
+		return errors.Wrap(err, "db.Exec")
+	}
+	return nil
+}
+
+// Drop drop detached partition table with email ids lookups of its range, delivery attempts are deleted by cascade
+func (p *partitionPGRepository) Drop(ctx context.Context, partition *models.EmailsPartition) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "partitionPGRepository.Drop")
+	defer span.Finish()
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "db.Begin")
+	}
+	defer tx.Rollback(ctx) // nolint: errcheck
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(dropPartitionQuery, pgx.Identifier{partition.Name}.Sanitize())); err != nil {
+		return errors.Wrap(err, "tx.Exec drop")
+	}
+	if _, err := tx.Exec(ctx, deletePartitionEmailIDsQuery, partition.From, partition.To); err != nil {
+		return errors.Wrap(err, "tx.Exec email ids")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "tx.Commit")
+	}
+	return nil
+}
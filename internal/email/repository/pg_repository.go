@@ -3,22 +3,32 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/AleksK1NG/nats-streaming/internal/models"
-	"github.com/AleksK1NG/nats-streaming/pkg/utils"
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	"github.com/AleksK1NG/nats-streaming/pkg/postgresql"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/satori/go.uuid"
 )
 
+// domain errors resource types
+const (
+	resourceEmail   = "email"
+	resourceWebhook = "webhook"
+)
+
 type emailPGRepository struct {
-	db *pgxpool.Pool
+	db      *pgxpool.Pool
+	cluster *postgresql.Cluster
 }
 
-// NewEmailPGRepository Email postgresql repository constructor
-func NewEmailPGRepository(db *pgxpool.Pool) *emailPGRepository {
-	return &emailPGRepository{db: db}
+// NewEmailPGRepository Email postgresql repository constructor, writes go to primary, GetByID and Search to replicas
+func NewEmailPGRepository(cluster *postgresql.Cluster) *emailPGRepository {
+	return &emailPGRepository{db: cluster.Primary(), cluster: cluster}
 }
 
 // Create create new email
@@ -26,82 +36,696 @@ func (e *emailPGRepository) Create(ctx context.Context, email *models.Email) (*m
 	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.Create")
 	defer span.Finish()
 
-	var mail models.Email
-	if err := e.db.QueryRow(
+	mail, err := scanEmail(e.db.QueryRow(
 		ctx,
 		createEmailQuery,
 		&email.From,
 		&email.To,
 		&email.Subject,
 		&email.Message,
-	).Scan(&mail.EmailID, &mail.From, &mail.To, &mail.Subject, &mail.Message, &mail.CreatedAt); err != nil {
+		email.GetPriority(),
+		email.GetLanguage(),
+		email.TenantID,
+	))
+	if err != nil {
 		return nil, errors.Wrap(err, "Scan")
 	}
 
-	return &mail, nil
+	return mail, nil
+}
+
+// CreateBatch create emails in single transaction using one batched round trip, returns created emails in the same order
+func (e *emailPGRepository) CreateBatch(ctx context.Context, emails []*models.Email) ([]*models.Email, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.CreateBatch")
+	defer span.Finish()
+
+	tx, err := e.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.Begin")
+	}
+	defer tx.Rollback(ctx) // nolint: errcheck
+
+	batch := &pgx.Batch{}
+	for _, email := range emails {
+		batch.Queue(
+			createEmailQuery,
+			email.From,
+			email.To,
+			email.Subject,
+			email.Message,
+			email.GetPriority(),
+			email.GetLanguage(),
+			email.TenantID,
+		)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	created := make([]*models.Email, 0, len(emails))
+	for i := range emails {
+		mail, err := scanEmail(results.QueryRow())
+		if err != nil {
+			results.Close()
+			return nil, errors.Wrapf(err, "Scan item: %d", i)
+		}
+		created = append(created, mail)
+	}
+	if err := results.Close(); err != nil {
+		return nil, errors.Wrap(err, "results.Close")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "tx.Commit")
+	}
+
+	return created, nil
 }
 
-// GetByID get single email by id
+// GetByID get single email by id, created_at of email is read from email ids lookup so only its partition is scanned
 func (e *emailPGRepository) GetByID(ctx context.Context, emailID uuid.UUID) (*models.Email, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.GetByID")
 	defer span.Finish()
 
-	var mail models.Email
-	if err := e.db.QueryRow(ctx, getByIDQuery, emailID).Scan(
-		&mail.EmailID, &mail.From, &mail.To, &mail.Subject, &mail.Message, &mail.CreatedAt,
-	); err != nil {
+	mail, err := scanEmail(e.cluster.Reader(ctx).QueryRow(ctx, getByIDQuery, emailID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NewNotFound(resourceEmail, emailID.String(), err)
+		}
 		return nil, errors.Wrap(err, "Scan")
 	}
 
-	return &mail, nil
+	return mail, nil
 }
 
-// Search search email using postgresql full text search
-func (e *emailPGRepository) Search(ctx context.Context, search string, pagination *utils.Pagination) (*models.EmailsList, error) {
+// Search search email using postgresql full text search and structured filters, with keyset pagination
+// if query cursor is set or offset pagination otherwise, total count is optional
+func (e *emailPGRepository) Search(ctx context.Context, query *models.SearchQuery) (*models.EmailsList, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.Search")
 	defer span.Finish()
 
-	searchWord := fmt.Sprintf("%s:*", search)
-	var count int
-	if err := e.db.QueryRow(ctx, searchTotalCountQuery, searchWord).Scan(&count); err != nil {
-		return nil, errors.Wrap(err, "QueryRow")
+	pagination := query.Pagination
+	builder := newSearchBuilder(query)
+	db := e.cluster.Reader(ctx)
+
+	list := &models.EmailsList{Size: int64(pagination.GetSize()), Emails: make([]*models.Email, 0)}
+	if query.Cursor == nil {
+		list.Page = int64(pagination.GetPage())
 	}
-	if count == 0 {
-		return &models.EmailsList{
-			TotalCount: 0,
-			TotalPages: 0,
-			Page:       0,
-			Size:       0,
-			HasMore:    false,
-			Emails:     make([]*models.Email, 0),
-		}, nil
+
+	if !query.SkipTotalCount {
+		var count int
+		countQuery, args := builder.countQuery()
+		if err := db.QueryRow(ctx, countQuery, args...).Scan(&count); err != nil {
+			return nil, errors.Wrap(err, "QueryRow")
+		}
+		if count == 0 {
+			return &models.EmailsList{
+				TotalCount: 0,
+				TotalPages: 0,
+				Page:       0,
+				Size:       0,
+				HasMore:    false,
+				Emails:     make([]*models.Email, 0),
+			}, nil
+		}
+		list.TotalCount = int64(count)
+		list.TotalPages = int64(pagination.GetTotalPages(count))
 	}
 
-	rows, err := e.db.Query(ctx, searchQuery, searchWord, pagination.GetOffset(), pagination.GetLimit())
+	// one extra row is fetched to know if there are more emails without counting them
+	limit := pagination.GetLimit()
+	listQuery, args := builder.listQuery(query, limit+1)
+	rows, err := db.Query(ctx, listQuery, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "db.Query")
 	}
 	defer rows.Close()
 
-	emailList := make([]*models.Email, 0, count)
 	for rows.Next() {
-		var m models.Email
-		if err := rows.Scan(&m.EmailID, &m.To, &m.From, &m.Subject, &m.Message, &m.CreatedAt); err != nil {
+		m, err := scanSearchEmail(rows)
+		if err != nil {
 			return nil, errors.Wrap(err, " rows.Scan")
 		}
-		emailList = append(emailList, &m)
+		if m.Snippet != "" {
+			m.Snippet = query.Highlight.FormatSnippet(m.Snippet)
+		}
+		list.Emails = append(list.Emails, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows.Err")
+	}
+
+	if len(list.Emails) > limit {
+		list.Emails = list.Emails[:limit]
+		list.HasMore = true
+
+		nextCursor, err := models.NewSearchCursor(list.Emails[limit-1], query.Order).Encode()
+		if err != nil {
+			return nil, errors.Wrap(err, "SearchCursor.Encode")
+		}
+		list.NextCursor = nextCursor
+	}
+
+	return list, nil
+}
+
+// Export pass all emails matching search query to fn in search order, emails are read by server side cursor
+// in batches of fetchSize inside read only transaction, so memory usage doesn't depend on number of exported emails
+func (e *emailPGRepository) Export(ctx context.Context, query *models.SearchQuery, fetchSize int, fn func(batch []*models.Email) error) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.Export")
+	defer span.Finish()
+
+	if fetchSize <= 0 {
+		return errors.Errorf("invalid export fetch size: %d", fetchSize)
+	}
+
+	tx, err := e.cluster.Reader(ctx).BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return errors.Wrap(err, "db.BeginTx")
+	}
+	defer tx.Rollback(ctx) // nolint: errcheck
+
+	exportQuery, args := newSearchBuilder(query).exportQuery(query)
+	if _, err := tx.Exec(ctx, declareExportCursorQuery+exportQuery, args...); err != nil {
+		return errors.Wrap(err, "tx.Exec")
+	}
+
+	fetchQuery := fmt.Sprintf(fetchExportCursorQuery, fetchSize)
+	batch := make([]*models.Email, 0, fetchSize)
+	for {
+		batch = batch[:0]
+		rows, err := tx.Query(ctx, fetchQuery)
+		if err != nil {
+			return errors.Wrap(err, "tx.Query")
+		}
+		for rows.Next() {
+			m, err := scanEmail(rows)
+			if err != nil {
+				rows.Close()
+				return errors.Wrap(err, "rows.Scan")
+			}
+			batch = append(batch, m)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return errors.Wrap(err, "rows.Err")
+		}
+
+		if len(batch) > 0 {
+			if err := fn(batch); err != nil {
+				return err
+			}
+		}
+		if len(batch) < fetchSize {
+			return nil
+		}
+	}
+}
+
+// Update edit recipient, subject and message of email which is not sending yet and increment its version,
+// sendingLease is lease of send worker claim, returns updated email
+func (e *emailPGRepository) Update(ctx context.Context, emailID uuid.UUID, req *models.UpdateEmailReq, sendingLease time.Duration) (*models.Email, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.Update")
+	defer span.Finish()
+
+	createdAt, err := e.getCreatedAt(ctx, emailID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NewNotFound(resourceEmail, emailID.String(), err)
+		}
+		return nil, errors.Wrap(err, "getCreatedAt")
+	}
+
+	mail, err := scanEmail(e.db.QueryRow(
+		ctx,
+		updateEmailQuery,
+		emailID,
+		req.Version,
+		req.To,
+		req.Subject,
+		req.Message,
+		sendingLease.Seconds(),
+		createdAt,
+	))
+	if err == nil {
+		return mail, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, errors.Wrap(err, "Scan")
+	}
+
+	// email is not updated, current email is read from primary to report the reason
+	current, err := scanEmail(e.db.QueryRow(ctx, getByIDQuery, emailID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NewNotFound(resourceEmail, emailID.String(), err)
+		}
+		return nil, errors.Wrap(err, "Scan")
+	}
+	if current.Version != req.Version {
+		return nil, domainErrors.NewConflict(resourceEmail, emailID.String(), errors.Errorf("version: %d, current version: %d", req.Version, current.Version))
+	}
+	return nil, errors.Wrapf(models.ErrEmailNotEditable, "status: %s", current.Status)
+}
+
+// ClaimForSending claim queued email for send worker until sending lease expires, returns current email
+// or nil if email is not queued, is erased or is claimed by other worker
+func (e *emailPGRepository) ClaimForSending(ctx context.Context, emailID uuid.UUID, sendingLease time.Duration) (*models.Email, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.ClaimForSending")
+	defer span.Finish()
+
+	createdAt, err := e.getCreatedAt(ctx, emailID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "getCreatedAt")
+	}
+
+	mail, err := scanEmail(e.db.QueryRow(ctx, claimForSendingQuery, emailID, sendingLease.Seconds(), createdAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Scan")
+	}
+	return mail, nil
+}
+
+// SetSent mark email as sent, returns updated email
+func (e *emailPGRepository) SetSent(ctx context.Context, emailID uuid.UUID, attempts int) (*models.Email, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.SetSent")
+	defer span.Finish()
+
+	createdAt, err := e.getCreatedAt(ctx, emailID)
+	if err != nil {
+		return nil, errors.Wrap(err, "getCreatedAt")
+	}
+
+	mail, err := scanEmail(e.db.QueryRow(ctx, setSentQuery, emailID, attempts, createdAt))
+	if err != nil {
+		return nil, errors.Wrap(err, "Scan")
+	}
+	return mail, nil
+}
+
+// SetRetrying schedule next send attempt of queued email, returns updated email
+func (e *emailPGRepository) SetRetrying(
+	ctx context.Context,
+	emailID uuid.UUID,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastError *models.DeliveryError,
+) (*models.Email, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.SetRetrying")
+	defer span.Finish()
+
+	createdAt, err := e.getCreatedAt(ctx, emailID)
+	if err != nil {
+		return nil, errors.Wrap(err, "getCreatedAt")
+	}
+
+	mail, err := scanEmail(e.db.QueryRow(
+		ctx,
+		setRetryingQuery,
+		emailID,
+		attempts,
+		nextAttemptAt,
+		lastError.Message,
+		lastError.Class,
+		lastError.Code,
+		lastError.EnhancedCode,
+		createdAt,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "Scan")
+	}
+	return mail, nil
+}
+
+// SetFailed mark queued email as failed, no more send attempts will be made, returns updated email
+func (e *emailPGRepository) SetFailed(ctx context.Context, emailID uuid.UUID, attempts int, lastError *models.DeliveryError) (*models.Email, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.SetFailed")
+	defer span.Finish()
+
+	createdAt, err := e.getCreatedAt(ctx, emailID)
+	if err != nil {
+		return nil, errors.Wrap(err, "getCreatedAt")
+	}
+
+	mail, err := scanEmail(e.db.QueryRow(
+		ctx,
+		setFailedQuery,
+		emailID,
+		attempts,
+		lastError.Message,
+		lastError.Class,
+		lastError.Code,
+		lastError.EnhancedCode,
+		createdAt,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "Scan")
+	}
+	return mail, nil
+}
+
+// ClaimDueRetries move emails with due send attempt back to queued status and return them,
+// locked rows are skipped so concurrent replicas claim different emails
+func (e *emailPGRepository) ClaimDueRetries(ctx context.Context, limit int) ([]*models.Email, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.ClaimDueRetries")
+	defer span.Finish()
+
+	rows, err := e.db.Query(ctx, claimDueRetriesQuery, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.Query")
+	}
+	defer rows.Close()
+
+	emails := make([]*models.Email, 0, limit)
+	for rows.Next() {
+		m, err := scanEmail(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		emails = append(emails, m)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, errors.Wrap(err, "rows.Err")
 	}
 
-	return &models.EmailsList{
-		TotalCount: int64(count),
-		TotalPages: int64(pagination.GetTotalPages(count)),
-		Page:       int64(pagination.GetPage()),
-		Size:       int64(pagination.GetSize()),
-		HasMore:    pagination.GetHasMore(count),
-		Emails:     emailList,
-	}, nil
+	return emails, nil
+}
+
+// ReleaseRetries move claimed emails which were not published back to retrying status with next attempt at
+// nextAttemptAt, emails already claimed by send worker are skipped, returns updated emails.
+// Created at of claimed emails limits update to their partitions.
+func (e *emailPGRepository) ReleaseRetries(ctx context.Context, claimed []*models.Email, nextAttemptAt time.Time) ([]*models.Email, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.ReleaseRetries")
+	defer span.Finish()
+
+	ids := make([]string, 0, len(claimed))
+	createdAt := make([]time.Time, 0, len(claimed))
+	for _, m := range claimed {
+		ids = append(ids, m.EmailID.String())
+		createdAt = append(createdAt, m.CreatedAt)
+	}
+
+	rows, err := e.db.Query(ctx, releaseRetriesQuery, ids, nextAttemptAt, createdAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.Query")
+	}
+	defer rows.Close()
+
+	emails := make([]*models.Email, 0, len(claimed))
+	for rows.Next() {
+		m, err := scanEmail(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		emails = append(emails, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows.Err")
+	}
+
+	return emails, nil
+}
+
+// CreateDeliveryAttempt save send attempt of email
+func (e *emailPGRepository) CreateDeliveryAttempt(ctx context.Context, attempt *models.DeliveryAttempt) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.CreateDeliveryAttempt")
+	defer span.Finish()
+
+	deliveryErr := attempt.Error
+	if deliveryErr == nil {
+		deliveryErr = &models.DeliveryError{}
+	}
+
+	if _, err := e.db.Exec(
+		ctx,
+		createDeliveryAttemptQuery,
+		attempt.EmailID,
+		attempt.Attempt,
+		attempt.WorkerID,
+		attempt.Relay,
+		attempt.Status,
+		attempt.StartedAt,
+		attempt.FinishedAt,
+		deliveryErr.Class,
+		deliveryErr.Code,
+		deliveryErr.EnhancedCode,
+		deliveryErr.Message,
+	); err != nil {
+		return errors.Wrap(err, "db.Exec")
+	}
+	return nil
+}
+
+// GetDeliveryAttempts get all send attempts of email ordered by attempt number
+func (e *emailPGRepository) GetDeliveryAttempts(ctx context.Context, emailID uuid.UUID) ([]*models.DeliveryAttempt, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.GetDeliveryAttempts")
+	defer span.Finish()
+
+	rows, err := e.db.Query(ctx, getDeliveryAttemptsQuery, emailID)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.Query")
+	}
+	defer rows.Close()
+
+	attempts := make([]*models.DeliveryAttempt, 0)
+	for rows.Next() {
+		var a models.DeliveryAttempt
+		var deliveryErr models.DeliveryError
+		if err := rows.Scan(
+			&a.AttemptID,
+			&a.EmailID,
+			&a.Attempt,
+			&a.WorkerID,
+			&a.Relay,
+			&a.Status,
+			&a.StartedAt,
+			&a.FinishedAt,
+			&deliveryErr.Class,
+			&deliveryErr.Code,
+			&deliveryErr.EnhancedCode,
+			&deliveryErr.Message,
+		); err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		if deliveryErr.Message != "" {
+			a.Error = &deliveryErr
+		}
+		attempts = append(attempts, &a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows.Err")
+	}
+
+	return attempts, nil
+}
+
+// GetExpired get emails created before given time matching retention status and tenant, oldest first
+func (e *emailPGRepository) GetExpired(ctx context.Context, status string, tenantID string, before time.Time, limit int) ([]*models.Email, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.GetExpired")
+	defer span.Finish()
+
+	rows, err := e.db.Query(ctx, getExpiredEmailsQuery, before, status, tenantID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.Query")
+	}
+	defer rows.Close()
+
+	emails := make([]*models.Email, 0, limit)
+	for rows.Next() {
+		m, err := scanEmail(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		emails = append(emails, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows.Err")
+	}
+
+	return emails, nil
+}
+
+// DeleteByIDs delete emails with its delivery attempts and email ids lookups, returns number of deleted emails
+func (e *emailPGRepository) DeleteByIDs(ctx context.Context, emailIDs []uuid.UUID) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.DeleteByIDs")
+	defer span.Finish()
+
+	ids := make([]string, 0, len(emailIDs))
+	for _, id := range emailIDs {
+		ids = append(ids, id.String())
+	}
+
+	result, err := e.db.Exec(ctx, deleteEmailsQuery, ids)
+	if err != nil {
+		return 0, errors.Wrap(err, "db.Exec")
+	}
+	return result.RowsAffected(), nil
+}
+
+// CreateDeadLetter persist dead letter queue message
+func (e *emailPGRepository) CreateDeadLetter(ctx context.Context, deadLetter *models.DeadLetter) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.CreateDeadLetter")
+	defer span.Finish()
+
+	if _, err := e.db.Exec(
+		ctx,
+		createDeadLetterQuery,
+		deadLetter.EmailID,
+		deadLetter.From,
+		deadLetter.To,
+		deadLetter.Subject,
+		int64(deadLetter.Sequence),
+		deadLetter.Data,
+		deadLetter.Error,
+	); err != nil {
+		return errors.Wrap(err, "db.Exec")
+	}
+	return nil
+}
+
+// Erase delete or redact all emails, delivery attempts and dead letters of address in single transaction
+// and write audit entry, address is stored in audit only as hash
+func (e *emailPGRepository) Erase(ctx context.Context, req *models.ErasureReq) (*models.ErasureReport, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailPGRepository.Erase")
+	defer span.Finish()
+
+	tx, err := e.db.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.Begin")
+	}
+	defer tx.Rollback(ctx) // nolint: errcheck
+
+	rows, err := tx.Query(ctx, getEmailIDsByAddressQuery, req.Address)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Query")
+	}
+	report := &models.ErasureReport{Mode: req.GetMode(), EmailIDs: make([]uuid.UUID, 0)}
+	ids := make([]string, 0)
+	for rows.Next() {
+		var emailID uuid.UUID
+		if err := rows.Scan(&emailID); err != nil {
+			rows.Close()
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		report.EmailIDs = append(report.EmailIDs, emailID)
+		ids = append(ids, emailID.String())
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows.Err")
+	}
+
+	attemptsQuery, emailsQuery := deleteAttemptsByEmailIDsQuery, deleteEmailsByIDsQuery
+	emailsArgs := []interface{}{ids}
+	if report.Mode == models.ErasureModeRedact {
+		attemptsQuery, emailsQuery = redactAttemptsByEmailIDsQuery, redactEmailsByIDsQuery
+		emailsArgs = append(emailsArgs, req.Address, models.RedactedAddress, models.RedactedText)
+	}
+
+	attempts, err := tx.Exec(ctx, attemptsQuery, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Exec attempts")
+	}
+	report.Attempts = attempts.RowsAffected()
+
+	emails, err := tx.Exec(ctx, emailsQuery, emailsArgs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Exec emails")
+	}
+	report.Emails = emails.RowsAffected()
+
+	deadLetters, err := tx.Exec(ctx, deleteDeadLettersQuery, req.Address, ids)
+	if err != nil {
+		return nil, errors.Wrap(err, "tx.Exec dead letters")
+	}
+	report.DeadLetters = deadLetters.RowsAffected()
+
+	// webhook payloads are copies of email addresses, so delivery logs are deleted in both modes
+	if _, err := tx.Exec(ctx, deleteWebhookDeliveriesByEmailIDsQuery, ids); err != nil {
+		return nil, errors.Wrap(err, "tx.Exec webhook deliveries")
+	}
+
+	if err := tx.QueryRow(
+		ctx,
+		createErasureQuery,
+		models.HashAddress(req.Address),
+		report.Mode,
+		report.Emails,
+		report.Attempts,
+		report.DeadLetters,
+	).Scan(&report.ErasureID, &report.ErasedAt); err != nil {
+		return nil, errors.Wrap(err, "tx.QueryRow")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "tx.Commit")
+	}
+
+	return report, nil
+}
+
+// getCreatedAt get created_at of email from email ids lookup on primary, updates by id pass it
+// as partition key so only partition of email is planned
+func (e *emailPGRepository) getCreatedAt(ctx context.Context, emailID uuid.UUID) (time.Time, error) {
+	var createdAt time.Time
+	if err := e.db.QueryRow(ctx, getCreatedAtQuery, emailID).Scan(&createdAt); err != nil {
+		return time.Time{}, err
+	}
+	return createdAt, nil
+}
+
+func scanEmail(row pgx.Row) (*models.Email, error) {
+	return scanEmailColumns(row, false)
+}
+
+// scanSearchEmail scan email with search rank and snippet columns
+func scanSearchEmail(row pgx.Row) (*models.Email, error) {
+	return scanEmailColumns(row, true)
+}
+
+func scanEmailColumns(row pgx.Row, withRank bool) (*models.Email, error) {
+	var m models.Email
+	var lastError models.DeliveryError
+	dest := []interface{}{
+		&m.EmailID,
+		&m.TenantID,
+		&m.From,
+		&m.To,
+		&m.Subject,
+		&m.Message,
+		&m.Priority,
+		&m.Language,
+		&m.Status,
+		&m.Attempts,
+		&m.NextAttemptAt,
+		&lastError.Message,
+		&lastError.Class,
+		&lastError.Code,
+		&lastError.EnhancedCode,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+		&m.Version,
+	}
+	if withRank {
+		dest = append(dest, &m.Rank, &m.Snippet)
+	}
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if lastError.Message != "" {
+		m.LastError = &lastError
+	}
+	return &m, nil
 }
@@ -39,6 +39,19 @@ func (e *emailRedisRepository) SetEmail(ctx context.Context, email *models.Email
 	return e.redis.SetEX(ctx, e.createKey(email.EmailID), string(emailBytes), expiration).Err()
 }
 
+// SetEmailNX cache email only if it is not cached yet, so value read from lagging replica never overwrites fresh one
+func (e *emailRedisRepository) SetEmailNX(ctx context.Context, email *models.Email) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailRedisRepository.SetEmailNX")
+	defer span.Finish()
+
+	emailBytes, err := json.Marshal(email)
+	if err != nil {
+		return errors.Wrap(err, "emailRedisRepository.Marshal")
+	}
+
+	return e.redis.SetNX(ctx, e.createKey(email.EmailID), string(emailBytes), expiration).Err()
+}
+
 func (e *emailRedisRepository) GetEmailByID(ctx context.Context, emailID uuid.UUID) (*models.Email, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "emailRedisRepository.GetEmailByID")
 	defer span.Finish()
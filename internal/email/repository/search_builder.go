@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+)
+
+// orderColumn sql column and type of whitelisted search sort field
+type orderColumn struct {
+	name    string
+	sqlType string
+}
+
+var searchOrderColumns = map[string]orderColumn{
+	models.OrderByCreatedAt: {name: "created_at", sqlType: "timestamptz"},
+	models.OrderByUpdatedAt: {name: "updated_at", sqlType: "timestamptz"},
+	models.OrderBySubject:   {name: "subject", sqlType: "text"},
+	models.OrderByFrom:      {name: "address_from", sqlType: "text"},
+	models.OrderByTo:        {name: "address_to", sqlType: "text"},
+	models.OrderByStatus:    {name: "status", sqlType: "text"},
+	models.OrderByPriority:  {name: "priority", sqlType: "text"},
+	models.OrderByRank:      {sqlType: "real"},
+}
+
+// searchBuilder builds parameterized search queries, user input is passed only as query arguments
+type searchBuilder struct {
+	conditions []string
+	args       []interface{}
+	tsQuery    string
+	language   string
+}
+
+func newSearchBuilder(query *models.SearchQuery) *searchBuilder {
+	b := &searchBuilder{conditions: []string{"deleted_at IS NULL"}}
+
+	if query.TSQuery != "" {
+		tsQuery := b.arg(query.TSQuery)
+		if query.Language != "" {
+			b.language = fmt.Sprintf("CAST(%s::text AS regconfig), ", b.arg(query.Language))
+			b.tsQuery = fmt.Sprintf("to_tsquery(%s%s)", b.language, tsQuery)
+			b.conditions = append(b.conditions, "document_with_idx @@ "+b.tsQuery)
+		} else {
+			// document is indexed with language of email, so without hint query is compiled with language of each email,
+			// it can't use index, so rows are prefiltered by union of query compiled with all languages which is its superset
+			b.language = "language, "
+			b.tsQuery = fmt.Sprintf("to_tsquery(language, %s)", tsQuery)
+			b.conditions = append(b.conditions, "document_with_idx @@ "+anyLanguageTSQuery(tsQuery), "document_with_idx @@ "+b.tsQuery)
+		}
+	}
+
+	filter := query.Filter
+	if filter.From != "" {
+		b.where("address_from = %s", filter.From)
+	}
+	if filter.To != "" {
+		b.where("address_to = %s", filter.To)
+	}
+	if filter.Status != "" {
+		b.where("status = %s", filter.Status)
+	}
+	if filter.SubjectPrefix != "" {
+		b.where(`subject LIKE %s ESCAPE '\'`, escapeLike(filter.SubjectPrefix)+"%")
+	}
+	if filter.CreatedFrom != nil {
+		b.where("created_at >= %s", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		b.where("created_at < %s", *filter.CreatedTo)
+	}
+
+	return b
+}
+
+// where add condition, %s in condition is replaced with placeholder of arg
+func (b *searchBuilder) where(condition string, arg interface{}) {
+	b.conditions = append(b.conditions, fmt.Sprintf(condition, b.arg(arg)))
+}
+
+func (b *searchBuilder) arg(arg interface{}) string {
+	b.args = append(b.args, arg)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *searchBuilder) whereClause() string {
+	return " WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// countQuery returns total count query, must be called before any pagination is applied
+func (b *searchBuilder) countQuery() (string, []interface{}) {
+	return searchTotalCountQuery + b.whereClause(), b.args
+}
+
+// listQuery returns search query with rank and highlighted snippet ordered by whitelisted column and email id,
+// paginated by keyset if cursor is set or by offset otherwise
+func (b *searchBuilder) listQuery(query *models.SearchQuery, limit int) (string, []interface{}) {
+	rank, snippet := "0::real", "''"
+	if b.tsQuery != "" {
+		rank = fmt.Sprintf("ts_rank_cd(document_with_idx, %s)", b.tsQuery)
+		// markers are removed from message, so only matched terms are wrapped by them
+		snippet = fmt.Sprintf("ts_headline(%stranslate(message, chr(2) || chr(3), ''), %s, %s)", b.language, b.tsQuery, b.arg(headlineOptions(query.Highlight)))
+	}
+
+	column, direction, compare := queryOrderColumn(query, rank)
+	if query.Cursor != nil {
+		b.conditions = append(b.conditions, fmt.Sprintf("(%s, email_id) %s (CAST(%s::text AS %s), %s)",
+			column.name, compare, b.arg(query.Cursor.Value), column.sqlType, b.arg(query.Cursor.EmailID)))
+	}
+
+	sql := fmt.Sprintf("%s%s ORDER BY %s %s, email_id %s", fmt.Sprintf(searchQuery, rank, snippet), b.whereClause(), column.name, direction, direction)
+	if query.Cursor == nil {
+		sql += " OFFSET " + b.arg(query.Pagination.GetOffset())
+	}
+	sql += " LIMIT " + b.arg(limit)
+
+	return sql, b.args
+}
+
+// exportQuery returns query of all matching emails in search order without rank, snippet and pagination
+func (b *searchBuilder) exportQuery(query *models.SearchQuery) (string, []interface{}) {
+	rank := "0::real"
+	if b.tsQuery != "" {
+		rank = fmt.Sprintf("ts_rank_cd(document_with_idx, %s)", b.tsQuery)
+	}
+
+	column, direction, _ := queryOrderColumn(query, rank)
+	return fmt.Sprintf("%s%s ORDER BY %s %s, email_id %s", exportQuery, b.whereClause(), column.name, direction, direction), b.args
+}
+
+// queryOrderColumn returns whitelisted order column, direction and keyset comparison operator of query order
+func queryOrderColumn(query *models.SearchQuery, rank string) (orderColumn, string, string) {
+	column := searchOrderColumns[query.Order.Field]
+	if query.Order.Field == models.OrderByRank {
+		column.name = rank
+	}
+	if query.Order.Desc {
+		return column, "DESC", "<"
+	}
+	return column, "ASC", ">"
+}
+
+// anyLanguageTSQuery returns union of tsquery compiled with each supported language, languages are whitelisted
+func anyLanguageTSQuery(tsQuery string) string {
+	languages := models.Languages()
+	queries := make([]string, 0, len(languages))
+	for _, language := range languages {
+		queries = append(queries, fmt.Sprintf("to_tsquery('%s', %s)", language, tsQuery))
+	}
+	return "(" + strings.Join(queries, " || ") + ")"
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// headlineOptions format ts_headline options, matched terms are wrapped by snippet markers which are
+// replaced by highlight selectors after snippet is escaped, zero values fallback to postgresql defaults
+func headlineOptions(h models.HighlightOptions) string {
+	options := make([]string, 0, 5)
+	options = append(options,
+		fmt.Sprintf(`StartSel="%s"`, models.SnippetStartMarker),
+		fmt.Sprintf(`StopSel="%s"`, models.SnippetStopMarker),
+	)
+	if h.MaxWords > 0 {
+		options = append(options, fmt.Sprintf("MaxWords=%d", h.MaxWords))
+	}
+	if h.MinWords > 0 {
+		options = append(options, fmt.Sprintf("MinWords=%d", h.MinWords))
+	}
+	if h.MaxFragments > 0 {
+		options = append(options, fmt.Sprintf("MaxFragments=%d", h.MaxFragments))
+	}
+	return strings.Join(options, ", ")
+}
@@ -1,17 +1,202 @@
 package repository
 
 const (
-	createEmailQuery = `INSERT INTO emails (address_from, address_to, subject, message) 
-	VALUES ($1, $2, $3, $4) 
-	RETURNING email_id, address_from, address_to, subject, message, created_at`
+	emailColumns = `email_id, tenant_id, address_from, address_to, subject, message, priority, language::text, status, attempts, next_attempt_at, 
+	COALESCE(last_error, ''), COALESCE(last_error_class, ''), COALESCE(last_error_code, 0), COALESCE(last_error_enhanced_code, ''), 
+	created_at, updated_at, version`
 
-	getByIDQuery = `SELECT email_id, address_from, address_to, subject, message, created_at FROM emails WHERE email_id = $1`
+	createEmailQuery = `INSERT INTO emails (address_from, address_to, subject, message, priority, language, tenant_id) 
+	VALUES ($1, $2, $3, $4, $5, CAST($6::text AS regconfig), $7) 
+	RETURNING ` + emailColumns
 
-	searchTotalCountQuery = `SELECT count(email_id)
-	FROM emails
-	WHERE document_with_idx @@ to_tsquery($1)`
+	getByIDQuery = `SELECT ` + emailColumns + ` FROM emails 
+	WHERE email_id = $1 AND created_at = (SELECT created_at FROM email_ids WHERE email_id = $1) AND deleted_at IS NULL`
+
+	getCreatedAtQuery = `SELECT created_at FROM email_ids WHERE email_id = $1`
+
+	searchTotalCountQuery = `SELECT count(email_id) FROM emails`
+
+	searchQuery = `SELECT ` + emailColumns + `, %s AS rank, %s AS snippet FROM emails`
+
+	exportQuery = `SELECT ` + emailColumns + ` FROM emails`
+
+	declareExportCursorQuery = `DECLARE export_cursor NO SCROLL CURSOR FOR `
+
+	fetchExportCursorQuery = `FETCH FORWARD %d FROM export_cursor`
+
+	updateEmailQuery = `UPDATE emails SET address_to = COALESCE($3, address_to), subject = COALESCE($4, subject), 
+	message = COALESCE($5, message), version = version + 1, updated_at = now() 
+	WHERE email_id = $1 AND created_at = $7 AND version = $2 AND status IN ('queued', 'retrying') AND deleted_at IS NULL 
+	AND (sending_at IS NULL OR sending_at < now() - make_interval(secs => $6))
+	RETURNING ` + emailColumns
+
+	claimForSendingQuery = `UPDATE emails SET sending_at = now() 
+	WHERE email_id = $1 AND created_at = $3 AND status = 'queued' AND deleted_at IS NULL 
+	AND (sending_at IS NULL OR sending_at < now() - make_interval(secs => $2))
+	RETURNING ` + emailColumns
+
+	setSentQuery = `UPDATE emails SET status = 'sent', attempts = $2, next_attempt_at = NULL, last_error = NULL, 
+	last_error_class = NULL, last_error_code = NULL, last_error_enhanced_code = NULL, sending_at = NULL, updated_at = now() 
+	WHERE email_id = $1 AND created_at = $3
+	RETURNING ` + emailColumns
+
+	setRetryingQuery = `UPDATE emails SET status = 'retrying', attempts = $2, next_attempt_at = $3, last_error = $4, 
+	last_error_class = $5, last_error_code = NULLIF($6, 0), last_error_enhanced_code = NULLIF($7, ''), sending_at = NULL, updated_at = now() 
+	WHERE email_id = $1 AND created_at = $8 AND status = 'queued'
+	RETURNING ` + emailColumns
+
+	setFailedQuery = `UPDATE emails SET status = 'failed', attempts = $2, next_attempt_at = NULL, last_error = $3, 
+	last_error_class = $4, last_error_code = NULLIF($5, 0), last_error_enhanced_code = NULLIF($6, ''), sending_at = NULL, updated_at = now() 
+	WHERE email_id = $1 AND created_at = $7 AND status = 'queued'
+	RETURNING ` + emailColumns
 
-	searchQuery = `SELECT email_id, address_to, address_from, subject, message, created_at
+	claimDueRetriesQuery = `UPDATE emails SET status = 'queued', next_attempt_at = NULL, updated_at = now()
+	WHERE (email_id, created_at) IN (
+		SELECT email_id, created_at FROM emails WHERE status = 'retrying' AND next_attempt_at <= now() 
+		ORDER BY next_attempt_at LIMIT $1 FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + emailColumns
+
+	releaseRetriesQuery = `UPDATE emails SET status = 'retrying', next_attempt_at = $2, updated_at = now()
+	WHERE email_id = ANY(CAST($1::text[] AS uuid[])) AND created_at = ANY($3::timestamptz[]) AND status = 'queued' AND sending_at IS NULL
+	RETURNING ` + emailColumns
+
+	createDeliveryAttemptQuery = `INSERT INTO delivery_attempts (email_id, attempt, worker_id, relay, status, started_at, finished_at, 
+	error_class, error_code, error_enhanced_code, error) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, 0), NULLIF($10, ''), NULLIF($11, ''))`
+
+	getDeliveryAttemptsQuery = `SELECT attempt_id, email_id, attempt, worker_id, relay, status, started_at, finished_at, 
+	COALESCE(error_class, ''), COALESCE(error_code, 0), COALESCE(error_enhanced_code, ''), COALESCE(error, '') 
+	FROM delivery_attempts WHERE email_id = $1 ORDER BY attempt, started_at`
+
+	getExpiredEmailsQuery = `SELECT ` + emailColumns + `
 	FROM emails
-	WHERE document_with_idx @@ to_tsquery($1) ORDER BY created_at OFFSET $2 LIMIT $3`
+	WHERE created_at < $1 AND (status = $2 OR ($2 = '' AND status IN ('sent', 'failed'))) AND ($3 = '' OR tenant_id = $3)
+	ORDER BY created_at, email_id
+	LIMIT $4`
+
+	deleteEmailsQuery = `WITH attempts AS (DELETE FROM delivery_attempts WHERE email_id = ANY(CAST($1::text[] AS uuid[]))), 
+	ids AS (DELETE FROM email_ids WHERE email_id = ANY(CAST($1::text[] AS uuid[])))
+	DELETE FROM emails WHERE email_id = ANY(CAST($1::text[] AS uuid[]))`
+
+	listPartitionsQuery = `SELECT c.relname
+	FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid
+	WHERE i.inhparent = 'emails'::regclass AND c.relname LIKE 'emails\_p%'
+	ORDER BY c.relname`
+
+	partitionExistsQuery = `SELECT to_regclass($1) IS NOT NULL`
+
+	createPartitionTableQuery = `CREATE TABLE %s (LIKE emails INCLUDING DEFAULTS INCLUDING CONSTRAINTS)`
+
+	movePartitionRowsQuery = `WITH moved AS (
+		DELETE FROM emails_default WHERE created_at >= $1 AND created_at < $2 RETURNING *
+	)
+	INSERT INTO %s SELECT * FROM moved`
+
+	attachPartitionQuery = `ALTER TABLE emails ATTACH PARTITION %s FOR VALUES FROM ('%s') TO ('%s')`
+
+	detachPartitionQuery = `ALTER TABLE emails DETACH PARTITION %s`
+
+	dropPartitionQuery = `DROP TABLE IF EXISTS %s`
+
+	deletePartitionEmailIDsQuery = `DELETE FROM email_ids WHERE created_at >= $1 AND created_at < $2`
+
+	tryPartitionsLockQuery = `SELECT pg_try_advisory_lock($1)`
+
+	partitionsUnlockQuery = `SELECT pg_advisory_unlock($1)`
+
+	createDeadLetterQuery = `INSERT INTO dead_letters (email_id, address_from, address_to, subject, sequence, data, error) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	getEmailIDsByAddressQuery = `SELECT email_id FROM emails WHERE lower(address_from) = lower($1) OR lower(address_to) = lower($1)`
+
+	deleteAttemptsByEmailIDsQuery = `DELETE FROM delivery_attempts WHERE email_id = ANY(CAST($1::text[] AS uuid[]))`
+
+	deleteEmailsByIDsQuery = `WITH ids AS (DELETE FROM email_ids WHERE email_id = ANY(CAST($1::text[] AS uuid[])))
+	DELETE FROM emails WHERE email_id = ANY(CAST($1::text[] AS uuid[]))`
+
+	redactAttemptsByEmailIDsQuery = `UPDATE delivery_attempts SET error = NULL WHERE email_id = ANY(CAST($1::text[] AS uuid[]))`
+
+	redactEmailsByIDsQuery = `UPDATE emails SET 
+	address_from = CASE WHEN lower(address_from) = lower($2) THEN $3 ELSE address_from END, 
+	address_to = CASE WHEN lower(address_to) = lower($2) THEN $3 ELSE address_to END, 
+	subject = $4, message = $4, last_error = CASE WHEN last_error IS NULL THEN NULL ELSE $4 END, 
+	status = CASE WHEN status IN ('queued', 'retrying') THEN 'failed' ELSE status END, next_attempt_at = NULL, 
+	deleted_at = COALESCE(deleted_at, now()), updated_at = now() 
+	WHERE email_id = ANY(CAST($1::text[] AS uuid[]))`
+
+	deleteDeadLettersQuery = `DELETE FROM dead_letters 
+	WHERE lower(address_from) = lower($1) OR lower(address_to) = lower($1) OR email_id = ANY(CAST($2::text[] AS uuid[]))`
+
+	createErasureQuery = `INSERT INTO erasures (address_hash, mode, emails, attempts, dead_letters) 
+	VALUES ($1, $2, $3, $4, $5) 
+	RETURNING erasure_id, created_at`
+
+	deleteWebhookDeliveriesByEmailIDsQuery = `DELETE FROM webhook_deliveries WHERE email_id = ANY(CAST($1::text[] AS uuid[]))`
+
+	webhookColumns = `webhook_id, url, event_types, enabled, consecutive_failures, disabled_at, created_at, updated_at`
+
+	createWebhookQuery = `INSERT INTO webhooks (url, event_types, secret) 
+	VALUES ($1, $2, $3) 
+	RETURNING ` + webhookColumns
+
+	getWebhookByIDQuery = `SELECT ` + webhookColumns + ` FROM webhooks WHERE webhook_id = $1`
+
+	listWebhooksQuery = `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY created_at`
+
+	updateWebhookQuery = `UPDATE webhooks SET url = $2, event_types = $3, secret = COALESCE(NULLIF($4, ''), secret), 
+	consecutive_failures = CASE WHEN $5 AND NOT enabled THEN 0 ELSE consecutive_failures END, 
+	disabled_at = CASE WHEN $5 THEN NULL ELSE COALESCE(disabled_at, now()) END, 
+	enabled = $5, updated_at = now() 
+	WHERE webhook_id = $1 
+	RETURNING ` + webhookColumns
+
+	deleteWebhookQuery = `DELETE FROM webhooks WHERE webhook_id = $1`
+
+	enqueueWebhookDeliveriesQuery = `INSERT INTO webhook_deliveries (webhook_id, event_sequence, event_type, email_id, payload, next_attempt_at) 
+	SELECT webhook_id, $1, $2, $3, $4, now() FROM webhooks 
+	WHERE enabled AND (cardinality(event_types) = 0 OR $2 = ANY(event_types)) 
+	ON CONFLICT (webhook_id, event_sequence) DO NOTHING`
+
+	webhookDeliveryColumns = `d.delivery_id, d.webhook_id, d.event_sequence, d.event_type, d.email_id, d.payload, d.status, d.attempts, 
+	d.next_attempt_at, COALESCE(d.last_status_code, 0), COALESCE(d.last_error, ''), d.delivered_at, d.created_at, d.updated_at`
+
+	claimWebhookDeliveriesQuery = `WITH claimed AS (
+		UPDATE webhook_deliveries SET next_attempt_at = now() + make_interval(secs => $2), updated_at = now() 
+		WHERE delivery_id IN (
+			SELECT d.delivery_id FROM webhook_deliveries d JOIN webhooks w ON w.webhook_id = d.webhook_id 
+			WHERE d.status = 'pending' AND d.next_attempt_at <= now() AND w.enabled 
+			ORDER BY d.next_attempt_at LIMIT $1 FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING *
+	)
+	SELECT ` + webhookDeliveryColumns + `, w.url, w.secret 
+	FROM claimed d JOIN webhooks w ON w.webhook_id = d.webhook_id`
+
+	setWebhookDeliveredQuery = `WITH delivery AS (
+		UPDATE webhook_deliveries SET status = 'delivered', attempts = $2, last_status_code = $3, last_error = NULL, 
+		next_attempt_at = NULL, delivered_at = now(), updated_at = now() 
+		WHERE delivery_id = $1 
+		RETURNING webhook_id
+	)
+	UPDATE webhooks SET consecutive_failures = 0 
+	WHERE webhook_id IN (SELECT webhook_id FROM delivery) AND consecutive_failures > 0`
+
+	setWebhookDeliveryFailedQuery = `WITH delivery AS (
+		UPDATE webhook_deliveries SET status = CASE WHEN CAST($5 AS timestamptz) IS NULL THEN 'failed' ELSE 'pending' END, 
+		attempts = $2, last_status_code = NULLIF($3, 0), last_error = $4, next_attempt_at = $5, updated_at = now() 
+		WHERE delivery_id = $1 
+		RETURNING webhook_id
+	)
+	UPDATE webhooks SET consecutive_failures = consecutive_failures + 1, 
+	enabled = enabled AND NOT ($6 > 0 AND consecutive_failures + 1 >= $6), 
+	disabled_at = CASE WHEN enabled AND $6 > 0 AND consecutive_failures + 1 >= $6 THEN now() ELSE disabled_at END, 
+	updated_at = now() 
+	WHERE webhook_id IN (SELECT webhook_id FROM delivery) 
+	RETURNING $6 > 0 AND consecutive_failures = $6`
+
+	getWebhookDeliveriesQuery = `SELECT ` + webhookDeliveryColumns + ` 
+	FROM webhook_deliveries d WHERE d.webhook_id = $1 
+	ORDER BY d.created_at DESC 
+	LIMIT $2`
 )
@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+type webhookPGRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewWebhookPGRepository Webhooks postgresql repository constructor
+func NewWebhookPGRepository(db *pgxpool.Pool) *webhookPGRepository {
+	return &webhookPGRepository{db: db}
+}
+
+// Create create new webhook
+func (w *webhookPGRepository) Create(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookPGRepository.Create")
+	defer span.Finish()
+
+	created, err := scanWebhook(w.db.QueryRow(ctx, createWebhookQuery, webhook.URL, eventTypesOrEmpty(webhook.EventTypes), webhook.Secret))
+	if err != nil {
+		return nil, errors.Wrap(err, "Scan")
+	}
+	created.Secret = webhook.Secret
+
+	return created, nil
+}
+
+// GetByID find webhook by id
+func (w *webhookPGRepository) GetByID(ctx context.Context, webhookID uuid.UUID) (*models.Webhook, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookPGRepository.GetByID")
+	defer span.Finish()
+
+	webhook, err := scanWebhook(w.db.QueryRow(ctx, getWebhookByIDQuery, webhookID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NewNotFound(resourceWebhook, webhookID.String(), err)
+		}
+		return nil, errors.Wrap(err, "Scan")
+	}
+
+	return webhook, nil
+}
+
+// List get all webhooks
+func (w *webhookPGRepository) List(ctx context.Context) ([]*models.Webhook, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookPGRepository.List")
+	defer span.Finish()
+
+	rows, err := w.db.Query(ctx, listWebhooksQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.Query")
+	}
+	defer rows.Close()
+
+	webhooks := make([]*models.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows.Err")
+	}
+
+	return webhooks, nil
+}
+
+// Update update webhook, empty secret keeps current one
+func (w *webhookPGRepository) Update(ctx context.Context, webhookID uuid.UUID, req *models.UpdateWebhookReq) (*models.Webhook, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookPGRepository.Update")
+	defer span.Finish()
+
+	webhook, err := scanWebhook(w.db.QueryRow(
+		ctx,
+		updateWebhookQuery,
+		webhookID,
+		req.URL,
+		eventTypesOrEmpty(req.EventTypes),
+		req.Secret,
+		req.Enabled,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainErrors.NewNotFound(resourceWebhook, webhookID.String(), err)
+		}
+		return nil, errors.Wrap(err, "Scan")
+	}
+
+	return webhook, nil
+}
+
+// Delete delete webhook with its delivery log
+func (w *webhookPGRepository) Delete(ctx context.Context, webhookID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookPGRepository.Delete")
+	defer span.Finish()
+
+	result, err := w.db.Exec(ctx, deleteWebhookQuery, webhookID)
+	if err != nil {
+		return errors.Wrap(err, "db.Exec")
+	}
+	if result.RowsAffected() == 0 {
+		return domainErrors.NewNotFound(resourceWebhook, webhookID.String(), pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+// EnqueueDeliveries create pending deliveries of event to all enabled webhooks subscribed to its type,
+// already enqueued event is skipped, so redelivered events are not duplicated
+func (w *webhookPGRepository) EnqueueDeliveries(ctx context.Context, event *models.StatusEvent, payload []byte) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookPGRepository.EnqueueDeliveries")
+	defer span.Finish()
+
+	result, err := w.db.Exec(ctx, enqueueWebhookDeliveriesQuery, int64(event.Sequence), event.Type, event.EmailID, payload)
+	if err != nil {
+		return 0, errors.Wrap(err, "db.Exec")
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// ClaimDueDeliveries claim pending deliveries with due attempt of enabled webhooks, claimed deliveries
+// next attempt is postponed by lease, so deliveries of crashed dispatcher are claimed again after it
+func (w *webhookPGRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookPGRepository.ClaimDueDeliveries")
+	defer span.Finish()
+
+	rows, err := w.db.Query(ctx, claimWebhookDeliveriesQuery, limit, lease.Seconds())
+	if err != nil {
+		return nil, errors.Wrap(err, "db.Query")
+	}
+	defer rows.Close()
+
+	deliveries := make([]*models.WebhookDelivery, 0, limit)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows.Err")
+	}
+
+	return deliveries, nil
+}
+
+// SetDelivered mark delivery as delivered and reset consecutive failures of its webhook
+func (w *webhookPGRepository) SetDelivered(ctx context.Context, deliveryID uuid.UUID, attempts int, statusCode int) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookPGRepository.SetDelivered")
+	defer span.Finish()
+
+	if _, err := w.db.Exec(ctx, setWebhookDeliveredQuery, deliveryID, attempts, statusCode); err != nil {
+		return errors.Wrap(err, "db.Exec")
+	}
+
+	return nil
+}
+
+// SetFailed record failed delivery attempt, delivery is retried at nextAttemptAt or failed if it is nil,
+// webhook is disabled when its consecutive failures reach disableAfter, returns true if webhook was disabled by this attempt
+func (w *webhookPGRepository) SetFailed(
+	ctx context.Context,
+	deliveryID uuid.UUID,
+	attempts int,
+	statusCode int,
+	lastError string,
+	nextAttemptAt *time.Time,
+	disableAfter int,
+) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookPGRepository.SetFailed")
+	defer span.Finish()
+
+	var disabled bool
+	if err := w.db.QueryRow(
+		ctx,
+		setWebhookDeliveryFailedQuery,
+		deliveryID,
+		attempts,
+		statusCode,
+		lastError,
+		nextAttemptAt,
+		disableAfter,
+	).Scan(&disabled); err != nil {
+		// delivery or webhook was deleted while attempt was in flight
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "Scan")
+	}
+
+	return disabled, nil
+}
+
+// GetDeliveries get last deliveries of webhook
+func (w *webhookPGRepository) GetDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookPGRepository.GetDeliveries")
+	defer span.Finish()
+
+	rows, err := w.db.Query(ctx, getWebhookDeliveriesQuery, webhookID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.Query")
+	}
+	defer rows.Close()
+
+	deliveries := make([]*models.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows.Err")
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhook(row pgx.Row) (*models.Webhook, error) {
+	var webhook models.Webhook
+	var updatedAt *time.Time
+	if err := row.Scan(
+		&webhook.WebhookID,
+		&webhook.URL,
+		&webhook.EventTypes,
+		&webhook.Enabled,
+		&webhook.ConsecutiveFailures,
+		&webhook.DisabledAt,
+		&webhook.CreatedAt,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if updatedAt != nil {
+		webhook.UpdatedAt = *updatedAt
+	}
+	return &webhook, nil
+}
+
+func scanWebhookDelivery(row pgx.Row, withWebhook bool) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	var sequence int64
+	var updatedAt *time.Time
+	dest := []interface{}{
+		&delivery.DeliveryID,
+		&delivery.WebhookID,
+		&sequence,
+		&delivery.EventType,
+		&delivery.EmailID,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.DeliveredAt,
+		&delivery.CreatedAt,
+		&updatedAt,
+	}
+	if withWebhook {
+		dest = append(dest, &delivery.URL, &delivery.Secret)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	delivery.EventSequence = uint64(sequence)
+	if updatedAt != nil {
+		delivery.UpdatedAt = *updatedAt
+	}
+	return &delivery, nil
+}
+
+// eventTypesOrEmpty nil slice is encoded as NULL, empty array subscribes to all event types
+func eventTypesOrEmpty(eventTypes []string) []string {
+	if eventTypes == nil {
+		return []string{}
+	}
+	return eventTypes
+}
@@ -0,0 +1,89 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/config"
+	"github.com/AleksK1NG/nats-streaming/internal/email"
+	"github.com/AleksK1NG/nats-streaming/pkg/archive"
+	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const archiveTimeLayout = "20060102T150405Z"
+
+type retentionJob struct {
+	log     logger.Logger
+	cfg     *config.Config
+	emailUC email.UseCase
+}
+
+// NewRetentionJob emails retention job constructor
+func NewRetentionJob(log logger.Logger, cfg *config.Config, emailUC email.UseCase) *retentionJob {
+	return &retentionJob{log: log, cfg: cfg, emailUC: emailUC}
+}
+
+// Run apply retention rules every configured interval until context is done
+func (j *retentionJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.cfg.Retention.Interval * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, rule := range j.cfg.Retention.Rules {
+				purged, err := j.applyRule(ctx, rule)
+				if err != nil {
+					retentionErrors.WithLabelValues(rule.Name).Inc()
+					j.log.Errorf("retentionJob.applyRule %s: %v", rule.Name, err)
+				}
+				lastRunPurged.WithLabelValues(rule.Name).Set(float64(purged))
+				lastRunTimestamp.WithLabelValues(rule.Name).SetToCurrentTime()
+				if purged > 0 {
+					j.log.Infof("retention rule %s purged emails: %d", rule.Name, purged)
+				}
+			}
+		}
+	}
+}
+
+// applyRule archive and delete expired emails in batches to single archive file per run
+func (j *retentionJob) applyRule(ctx context.Context, rule config.RetentionRule) (purged int, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "retentionJob.applyRule")
+	defer span.Finish()
+
+	if rule.MaxAge <= 0 {
+		return 0, errors.Errorf("retention rule MaxAge must be positive, got: %v", rule.MaxAge)
+	}
+
+	name := fmt.Sprintf("emails-%s-%s", rule.Name, time.Now().UTC().Format(archiveTimeLayout))
+	w, err := archive.NewJSONLWriter(j.cfg.Retention.ArchiveDir, name)
+	if err != nil {
+		return 0, errors.Wrap(err, "archive.NewJSONLWriter")
+	}
+	defer func() {
+		if closeErr := w.Close(); closeErr != nil && err == nil {
+			err = errors.Wrap(closeErr, "archive.Close")
+		}
+	}()
+
+	for ctx.Err() == nil {
+		n, err := j.emailUC.PurgeExpired(ctx, rule, j.cfg.Retention.BatchSize, w)
+		if err != nil {
+			return purged, errors.Wrap(err, "emailUC.PurgeExpired")
+		}
+		purged += n
+		purgedEmails.WithLabelValues(rule.Name).Add(float64(n))
+
+		if n == 0 || n < j.cfg.Retention.BatchSize {
+			break
+		}
+	}
+
+	return purged, nil
+}
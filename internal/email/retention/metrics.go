@@ -0,0 +1,25 @@
+package retention
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	purgedEmails = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retention_purged_emails_total",
+		Help: "The total number of archived and deleted emails by retention rule",
+	}, []string{"rule"})
+	retentionErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retention_errors_total",
+		Help: "The total number of failed retention runs by retention rule",
+	}, []string{"rule"})
+	lastRunTimestamp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "retention_last_run_timestamp_seconds",
+		Help: "The unix time of last completed retention run by retention rule",
+	}, []string{"rule"})
+	lastRunPurged = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "retention_last_run_purged_emails",
+		Help: "The number of emails purged by last retention run by retention rule",
+	}, []string{"rule"})
+)
@@ -2,17 +2,47 @@ package email
 
 import (
 	"context"
+	"io"
 
+	"github.com/AleksK1NG/nats-streaming/config"
+	"github.com/AleksK1NG/nats-streaming/internal/email/events"
 	"github.com/AleksK1NG/nats-streaming/internal/models"
-	"github.com/AleksK1NG/nats-streaming/pkg/utils"
 	"github.com/satori/go.uuid"
 )
 
 // UseCase Email usecase interface
 type UseCase interface {
 	Create(ctx context.Context, email *models.Email) error
-	PublishCreate(ctx context.Context, email *models.Email) error
+	CreateBatch(ctx context.Context, emails []*models.Email) ([]*models.Email, error)
 	GetByID(ctx context.Context, emailID uuid.UUID) (*models.Email, error)
-	Search(ctx context.Context, search string, pagination *utils.Pagination) (*models.EmailsList, error)
-	SendEmail(ctx context.Context, email *models.Email) error
+	Search(ctx context.Context, query *models.SearchQuery) (*models.EmailsList, error)
+	Export(ctx context.Context, query *models.ExportQuery, w io.Writer) (int, error)
+	Update(ctx context.Context, emailID uuid.UUID, req *models.UpdateEmailReq) (*models.Email, error)
+	SendEmail(ctx context.Context, email *models.Email, workerID string) error
+	ScheduleRetry(ctx context.Context, email *models.Email, sendErr error) error
+	PublishDueRetries(ctx context.Context) (int, error)
+	GetDeliveryHistory(ctx context.Context, emailID uuid.UUID) (*models.DeliveryHistory, error)
+	PurgeExpired(ctx context.Context, rule config.RetentionRule, limit int, archive Archive) (int, error)
+	CreateDeadLetter(ctx context.Context, msg *models.EmailErrorMsg) error
+	Erase(ctx context.Context, req *models.ErasureReq) (*models.ErasureReport, error)
+	DispatchStatusEvent(event *models.StatusEvent)
+	WatchStatus(ctx context.Context, filter models.StatusFilter, afterSequence uint64) (*events.Subscription, error)
+}
+
+// WebhookUseCase outbound webhooks usecase interface
+type WebhookUseCase interface {
+	Create(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error)
+	GetByID(ctx context.Context, webhookID uuid.UUID) (*models.Webhook, error)
+	List(ctx context.Context) (*models.WebhooksList, error)
+	Update(ctx context.Context, webhookID uuid.UUID, req *models.UpdateWebhookReq) (*models.Webhook, error)
+	Delete(ctx context.Context, webhookID uuid.UUID) error
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) (*models.WebhookDeliveriesList, error)
+	Enqueue(ctx context.Context, event *models.StatusEvent) (int64, error)
+	DispatchDue(ctx context.Context) (int, error)
+}
+
+// Archive storage of purged emails
+type Archive interface {
+	Write(record interface{}) error
+	Flush() error
 }
@@ -3,35 +3,57 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"sync"
+	"time"
 
+	"github.com/AleksK1NG/nats-streaming/config"
 	"github.com/AleksK1NG/nats-streaming/internal/email"
 	"github.com/AleksK1NG/nats-streaming/internal/email/delivery/nats"
+	"github.com/AleksK1NG/nats-streaming/internal/email/events"
+	"github.com/AleksK1NG/nats-streaming/internal/email/export"
 	"github.com/AleksK1NG/nats-streaming/internal/models"
+	"github.com/AleksK1NG/nats-streaming/pkg/backoff"
 	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/AleksK1NG/nats-streaming/pkg/postgresql"
 	smtpClient "github.com/AleksK1NG/nats-streaming/pkg/smtp"
-	"github.com/AleksK1NG/nats-streaming/pkg/utils"
 	"github.com/go-redis/redis/v8"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"github.com/satori/go.uuid"
 )
 
-const (
-	createEmailSubject = "mail:create"
-	sendEmailSubject   = "mail:send"
-)
-
 type emailUseCase struct {
 	log         logger.Logger
+	cfg         *config.Config
 	emailPGRepo email.PGRepository
 	publisher   nats.Publisher
 	smtpClient  smtpClient.SMTPClient
 	redisRepo   email.RedisRepository
+	retryPolicy *backoff.Policy
+	statusBus   *events.Bus
 }
 
 // NewEmailUseCase email usecase constructor
-func NewEmailUseCase(log logger.Logger, emailPGRepo email.PGRepository, publisher nats.Publisher, smtpClient smtpClient.SMTPClient, redisRepo email.RedisRepository) *emailUseCase {
-	return &emailUseCase{log: log, emailPGRepo: emailPGRepo, publisher: publisher, smtpClient: smtpClient, redisRepo: redisRepo}
+func NewEmailUseCase(
+	log logger.Logger,
+	cfg *config.Config,
+	emailPGRepo email.PGRepository,
+	publisher nats.Publisher,
+	smtpClient smtpClient.SMTPClient,
+	redisRepo email.RedisRepository,
+	statusBus *events.Bus,
+) *emailUseCase {
+	return &emailUseCase{
+		log:         log,
+		cfg:         cfg,
+		emailPGRepo: emailPGRepo,
+		publisher:   publisher,
+		smtpClient:  smtpClient,
+		redisRepo:   redisRepo,
+		retryPolicy: backoff.NewPolicy(cfg.MailService.Retry),
+		statusBus:   statusBus,
+	}
 }
 
 // Create create new email saves in db
@@ -43,13 +65,76 @@ func (e *emailUseCase) Create(ctx context.Context, email *models.Email) error {
 	if err != nil {
 		return errors.Wrap(err, "emailPGRepo.Create")
 	}
+	e.cacheEmail(ctx, created)
+	e.publishStatusEvent(models.EventTypeCreated, created)
 
 	mailBytes, err := json.Marshal(created)
 	if err != nil {
 		return errors.Wrap(err, "json.Marshal")
 	}
 
-	return e.publisher.Publish(sendEmailSubject, mailBytes)
+	return e.publisher.Publish(e.sendEmailSubject(created.GetPriority()), mailBytes)
+}
+
+// CreateBatch create emails in batch and publish them asynchronously waiting for all acks,
+// emails which failed to publish are scheduled for immediate retry so they are republished by retry scheduler
+func (e *emailUseCase) CreateBatch(ctx context.Context, emails []*models.Email) ([]*models.Email, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.CreateBatch")
+	defer span.Finish()
+
+	created, err := e.emailPGRepo.CreateBatch(ctx, emails)
+	if err != nil {
+		return nil, errors.Wrap(err, "emailPGRepo.CreateBatch")
+	}
+
+	publishErrs := e.publishAsync(created)
+	for i, publishErr := range publishErrs {
+		if publishErr == nil {
+			e.cacheEmail(ctx, created[i])
+			e.publishStatusEvent(models.EventTypeCreated, created[i])
+			continue
+		}
+
+		e.log.Errorf("publish batch email: %s: %v", created[i].EmailID, publishErr)
+		deliveryErr := &models.DeliveryError{Class: smtpClient.ErrorClassTransient, Message: publishErr.Error()}
+		retrying, err := e.emailPGRepo.SetRetrying(ctx, created[i].EmailID, 0, time.Now().UTC(), deliveryErr)
+		if err != nil {
+			return nil, errors.Wrap(err, "emailPGRepo.SetRetrying")
+		}
+		created[i] = retrying
+		e.cacheEmail(ctx, retrying)
+		e.publishStatusEvent(models.EventTypeRetrying, retrying)
+	}
+
+	return created, nil
+}
+
+// publishAsync publish emails to its priority lanes without waiting for each ack, returns publish error of each email
+func (e *emailUseCase) publishAsync(emails []*models.Email) []error {
+	errs := make([]error, len(emails))
+
+	var wg sync.WaitGroup
+	for i, m := range emails {
+		mailBytes, err := json.Marshal(m)
+		if err != nil {
+			errs[i] = errors.Wrap(err, "json.Marshal")
+			continue
+		}
+
+		i := i
+		wg.Add(1)
+		if _, err := e.publisher.PublishAsync(e.sendEmailSubject(m.GetPriority()), mailBytes, func(_ string, err error) {
+			errs[i] = err
+			wg.Done()
+		}); err != nil {
+			errs[i] = errors.Wrap(err, "publisher.PublishAsync")
+			wg.Done()
+		}
+	}
+	// stan calls ack handler with timeout error if ack is not received, so wait always ends
+	wg.Wait()
+
+	return errs
 }
 
 // GetByID fnd email by id
@@ -70,46 +155,394 @@ func (e *emailUseCase) GetByID(ctx context.Context, emailID uuid.UUID) (*models.
 		return nil, errors.Wrap(err, "emailPGRepo.GetByID")
 	}
 
-	if err := e.redisRepo.SetEmail(ctx, mail); err != nil {
-		e.log.Errorf("redisRepo.SetEmail: %v", err)
+	// email may be read from replica, so it must not overwrite email cached by writes
+	if err := e.redisRepo.SetEmailNX(ctx, mail); err != nil {
+		e.log.Errorf("redisRepo.SetEmailNX: %v", err)
 	}
 
 	return mail, nil
 }
 
-// PublishCreate publish create email event to message broker
-func (e *emailUseCase) PublishCreate(ctx context.Context, email *models.Email) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "emailUseCase.PublishCreate")
+// Search search email in db, snippets are highlighted with configured markers
+func (e *emailUseCase) Search(ctx context.Context, query *models.SearchQuery) (*models.EmailsList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.Search")
 	defer span.Finish()
 
-	mailBytes, err := json.Marshal(email)
-	if err != nil {
-		return errors.Wrap(err, "json.Marshal")
+	query.Highlight = models.HighlightOptions{
+		StartSel:     e.cfg.Search.HighlightStartSel,
+		StopSel:      e.cfg.Search.HighlightStopSel,
+		MaxWords:     e.cfg.Search.SnippetMaxWords,
+		MinWords:     e.cfg.Search.SnippetMinWords,
+		MaxFragments: e.cfg.Search.SnippetMaxFragments,
 	}
-
-	return e.publisher.Publish(createEmailSubject, mailBytes)
+	return e.emailPGRepo.Search(ctx, query)
 }
 
-// Search search email in db
-func (e *emailUseCase) Search(ctx context.Context, search string, pagination *utils.Pagination) (*models.EmailsList, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.Search")
+// Export write emails matching export query to w, emails are streamed from db cursor
+// and w is flushed after each fetched batch, returns number of exported emails
+func (e *emailUseCase) Export(ctx context.Context, query *models.ExportQuery, w io.Writer) (int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.Export")
 	defer span.Finish()
-	return e.emailPGRepo.Search(ctx, search, pagination)
+
+	writer, err := export.NewWriter(w, query.Format, query.Gzip)
+	if err != nil {
+		return 0, errors.Wrap(err, "export.NewWriter")
+	}
+
+	if err := e.emailPGRepo.Export(ctx, query.Search, e.cfg.Search.ExportFetchSize, func(batch []*models.Email) error {
+		if err := writer.Write(batch); err != nil {
+			return err
+		}
+		return writer.Flush()
+	}); err != nil {
+		return writer.Count(), errors.Wrap(err, "emailPGRepo.Export")
+	}
+
+	if err := writer.Close(); err != nil {
+		return writer.Count(), errors.Wrap(err, "writer.Close")
+	}
+	return writer.Count(), nil
 }
 
-// SendEmail send email using smtp client
-func (e *emailUseCase) SendEmail(ctx context.Context, email *models.Email) error {
-	span, _ := opentracing.StartSpanFromContext(ctx, "emailUseCase.SendEmail")
+// SendEmail claim queued email, send it using smtp client, record delivery attempt and mark email as sent,
+// emails which are not queued anymore are skipped
+func (e *emailUseCase) SendEmail(ctx context.Context, email *models.Email, workerID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.SendEmail")
 	defer span.Finish()
 
-	if err := e.smtpClient.SendMail(&models.MailData{
+	// email is sent as it is stored, so edits made after it was published are applied
+	claimed, err := e.emailPGRepo.ClaimForSending(ctx, email.EmailID, e.cfg.MailService.SendingLease*time.Second)
+	if err != nil {
+		return errors.Wrap(err, "emailPGRepo.ClaimForSending")
+	}
+	if claimed == nil {
+		e.log.Infof("email: %s is not queued or is sending by other worker, skipped", email.EmailID)
+		return nil
+	}
+	email = claimed
+
+	attempt := &models.DeliveryAttempt{
+		EmailID:   email.EmailID,
+		Attempt:   email.Attempts + 1,
+		WorkerID:  workerID,
+		Relay:     e.smtpClient.Relay(),
+		Status:    models.AttemptStatusSent,
+		StartedAt: time.Now().UTC(),
+	}
+
+	sendErr := e.smtpClient.SendMail(&models.MailData{
 		To:      email.To,
 		From:    email.From,
 		Subject: email.Subject,
 		Content: email.Message,
-	}); err != nil {
-		return errors.Wrap(err, "SendMail")
+	})
+
+	attempt.FinishedAt = time.Now().UTC()
+	if sendErr != nil {
+		attempt.Status = models.AttemptStatusFailed
+		attempt.Error = smtpClient.AsSendError(sendErr).DeliveryError()
+	}
+	if err := e.emailPGRepo.CreateDeliveryAttempt(ctx, attempt); err != nil {
+		e.log.Errorf("emailPGRepo.CreateDeliveryAttempt: %v", err)
+	}
+
+	if sendErr != nil {
+		return errors.Wrap(sendErr, "SendMail")
+	}
+
+	sent, err := e.emailPGRepo.SetSent(ctx, email.EmailID, email.Attempts+1)
+	if err != nil {
+		e.log.Errorf("emailPGRepo.SetSent: %v", err)
+		e.invalidateCache(ctx, email.EmailID)
+		return nil
+	}
+	e.cacheEmail(ctx, sent)
+	e.publishStatusEvent(models.EventTypeSent, sent)
+
+	return nil
+}
+
+// ScheduleRetry record failed send attempt and schedule next one with exponential backoff,
+// returns ErrDeliveryFailed if error is permanent or retry policy is exhausted
+func (e *emailUseCase) ScheduleRetry(ctx context.Context, mail *models.Email, sendErr error) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.ScheduleRetry")
+	defer span.Finish()
+
+	attempts := mail.Attempts + 1
+	deliveryErr := smtpClient.AsSendError(sendErr).DeliveryError()
+	if smtpClient.IsPermanentError(sendErr) || e.retryPolicy.Exhausted(attempts, mail.CreatedAt) {
+		failed, err := e.emailPGRepo.SetFailed(ctx, mail.EmailID, attempts, deliveryErr)
+		if err != nil {
+			return errors.Wrap(err, "emailPGRepo.SetFailed")
+		}
+		e.cacheEmail(ctx, failed)
+		e.publishStatusEvent(models.EventTypeFailed, failed)
+		return errors.Wrapf(email.ErrDeliveryFailed, "attempts: %d, error: %v", attempts, sendErr)
+	}
+
+	nextAttemptAt := time.Now().UTC().Add(e.retryPolicy.Delay(attempts))
+	retrying, err := e.emailPGRepo.SetRetrying(ctx, mail.EmailID, attempts, nextAttemptAt, deliveryErr)
+	if err != nil {
+		return errors.Wrap(err, "emailPGRepo.SetRetrying")
+	}
+	e.cacheEmail(ctx, retrying)
+	e.publishStatusEvent(models.EventTypeRetrying, retrying)
+
+	e.log.Infof("email: %s send attempt: %d failed, next attempt at: %v", mail.EmailID, attempts, nextAttemptAt)
+	return nil
+}
+
+// PublishDueRetries publish emails with due send attempt to its priority lane, returns number of published emails,
+// if publish fails claimed emails which were not published are released back to retrying, so they are picked up again
+func (e *emailUseCase) PublishDueRetries(ctx context.Context) (int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.PublishDueRetries")
+	defer span.Finish()
+
+	emails, err := e.emailPGRepo.ClaimDueRetries(ctx, e.cfg.MailService.Retry.PollBatchSize)
+	if err != nil {
+		return 0, errors.Wrap(err, "emailPGRepo.ClaimDueRetries")
+	}
+
+	for i, m := range emails {
+		if err := e.publishSendEmail(m); err != nil {
+			e.releaseRetries(ctx, emails[i:])
+			return i, err
+		}
+		e.cacheEmail(ctx, m)
+		e.publishStatusEvent(models.EventTypeQueued, m)
+	}
+
+	return len(emails), nil
+}
+
+func (e *emailUseCase) publishSendEmail(mail *models.Email) error {
+	mailBytes, err := json.Marshal(mail)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+	if err := e.publisher.Publish(e.sendEmailSubject(mail.GetPriority()), mailBytes); err != nil {
+		return errors.Wrap(err, "publisher.Publish")
+	}
+	return nil
+}
+
+// releaseRetries release claimed emails with next attempt after poll interval, if release fails
+// emails stay queued without next attempt, so error is logged
+func (e *emailUseCase) releaseRetries(ctx context.Context, emails []*models.Email) {
+	nextAttemptAt := time.Now().UTC().Add(e.cfg.MailService.Retry.PollInterval * time.Second)
+	released, err := e.emailPGRepo.ReleaseRetries(ctx, emails, nextAttemptAt)
+	if err != nil {
+		emailIDs := make([]uuid.UUID, 0, len(emails))
+		for _, m := range emails {
+			emailIDs = append(emailIDs, m.EmailID)
+		}
+		e.log.Errorf("emailPGRepo.ReleaseRetries: %v, emails: %v", err, emailIDs)
+		return
+	}
+	for _, m := range released {
+		e.cacheEmail(ctx, m)
+	}
+}
+
+// Update edit email which is not sending yet, cached email is invalidated and search document is rebuilt by tsvector trigger
+func (e *emailUseCase) Update(ctx context.Context, emailID uuid.UUID, req *models.UpdateEmailReq) (*models.Email, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.Update")
+	defer span.Finish()
+
+	if req.Empty() {
+		return nil, models.ErrEmptyEmailUpdate
+	}
+
+	updated, err := e.emailPGRepo.Update(ctx, emailID, req, e.cfg.MailService.SendingLease*time.Second)
+	if err != nil {
+		return nil, errors.Wrap(err, "emailPGRepo.Update")
+	}
+	e.invalidateCache(ctx, emailID)
+
+	// lagging replica may still return previous version and cache it again, so cache is evicted once more after max lag
+	if len(e.cfg.PostgreSQL.Replicas) > 0 {
+		time.AfterFunc(e.cfg.PostgreSQL.MaxReplicaLag*time.Second, func() {
+			e.invalidateCache(context.Background(), emailID)
+		})
+	}
+
+	return updated, nil
+}
+
+// GetDeliveryHistory get all send attempts of email
+func (e *emailUseCase) GetDeliveryHistory(ctx context.Context, emailID uuid.UUID) (*models.DeliveryHistory, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.GetDeliveryHistory")
+	defer span.Finish()
+
+	attempts, err := e.emailPGRepo.GetDeliveryAttempts(ctx, emailID)
+	if err != nil {
+		return nil, errors.Wrap(err, "emailPGRepo.GetDeliveryAttempts")
+	}
+
+	// attempts are read from primary, so email existence is checked on primary too
+	if len(attempts) == 0 {
+		if _, err := e.emailPGRepo.GetByID(postgresql.WithPrimary(ctx), emailID); err != nil {
+			return nil, errors.Wrap(err, "emailPGRepo.GetByID")
+		}
+	}
+
+	return &models.DeliveryHistory{EmailID: emailID, Attempts: attempts}, nil
+}
+
+// PurgeExpired archive and delete one batch of emails expired by retention rule, returns number of purged emails
+func (e *emailUseCase) PurgeExpired(ctx context.Context, rule config.RetentionRule, limit int, archive email.Archive) (int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.PurgeExpired")
+	defer span.Finish()
+
+	before := time.Now().Add(-rule.MaxAge * time.Second)
+	emails, err := e.emailPGRepo.GetExpired(ctx, rule.Status, rule.TenantID, before, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "emailPGRepo.GetExpired")
+	}
+	if len(emails) == 0 {
+		return 0, nil
+	}
+
+	emailIDs := make([]uuid.UUID, 0, len(emails))
+	for _, m := range emails {
+		if err := archive.Write(m); err != nil {
+			return 0, errors.Wrap(err, "archive.Write")
+		}
+		emailIDs = append(emailIDs, m.EmailID)
+	}
+	// emails are deleted only after archive is durable on disk
+	if err := archive.Flush(); err != nil {
+		return 0, errors.Wrap(err, "archive.Flush")
+	}
+
+	deleted, err := e.emailPGRepo.DeleteByIDs(ctx, emailIDs)
+	if err != nil {
+		return 0, errors.Wrap(err, "emailPGRepo.DeleteByIDs")
+	}
+
+	for _, emailID := range emailIDs {
+		e.invalidateCache(ctx, emailID)
+	}
+
+	return int(deleted), nil
+}
+
+// CreateDeadLetter persist dead letter queue message, so it can be found and erased by address
+func (e *emailUseCase) CreateDeadLetter(ctx context.Context, msg *models.EmailErrorMsg) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.CreateDeadLetter")
+	defer span.Finish()
+
+	deadLetter := &models.DeadLetter{
+		Subject:  msg.Subject,
+		Sequence: msg.Sequence,
+		Data:     msg.Data,
+		Error:    msg.Error,
 	}
 
+	// message data is best effort parsed, malformed messages are stored without addresses
+	var m models.Email
+	if err := json.Unmarshal(msg.Data, &m); err == nil {
+		deadLetter.From = m.From
+		deadLetter.To = m.To
+		if m.EmailID != uuid.Nil {
+			deadLetter.EmailID = &m.EmailID
+		}
+	}
+
+	if err := e.emailPGRepo.CreateDeadLetter(ctx, deadLetter); err != nil {
+		return errors.Wrap(err, "emailPGRepo.CreateDeadLetter")
+	}
+	if deadLetter.EmailID != nil {
+		e.publishStatusEvent(models.EventTypeDeadLettered, &m)
+	}
 	return nil
 }
+
+// Erase erase all data of address from database and cache, returns erasure report
+func (e *emailUseCase) Erase(ctx context.Context, req *models.ErasureReq) (*models.ErasureReport, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "emailUseCase.Erase")
+	defer span.Finish()
+
+	report, err := e.emailPGRepo.Erase(ctx, req)
+	if err != nil {
+		return nil, errors.Wrap(err, "emailPGRepo.Erase")
+	}
+
+	for _, emailID := range report.EmailIDs {
+		if err := e.redisRepo.DeleteEmail(ctx, emailID); err != nil {
+			e.log.Errorf("redisRepo.DeleteEmail: %v", err)
+			continue
+		}
+		report.CacheEvicted++
+	}
+
+	// lagging replica may still return erased emails and cache them again, so cache is evicted once more after max lag
+	if len(e.cfg.PostgreSQL.Replicas) > 0 && len(report.EmailIDs) > 0 {
+		time.AfterFunc(e.cfg.PostgreSQL.MaxReplicaLag*time.Second, func() {
+			for _, emailID := range report.EmailIDs {
+				e.invalidateCache(context.Background(), emailID)
+			}
+		})
+	}
+
+	e.log.Infof("erasure %s: mode: %s, emails: %d, attempts: %d, dead letters: %d, cache evicted: %d",
+		report.ErasureID, report.Mode, report.Emails, report.Attempts, report.DeadLetters, report.CacheEvicted)
+	return report, nil
+}
+
+// DispatchStatusEvent deliver status event received from message broker to local watchers
+func (e *emailUseCase) DispatchStatusEvent(event *models.StatusEvent) {
+	e.statusBus.Publish(event)
+}
+
+// WatchStatus subscribe to status events matching filter, events after afterSequence are replayed first if still buffered
+func (e *emailUseCase) WatchStatus(ctx context.Context, filter models.StatusFilter, afterSequence uint64) (*events.Subscription, error) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "emailUseCase.WatchStatus")
+	defer span.Finish()
+
+	sub, err := e.statusBus.Subscribe(filter, afterSequence)
+	if err != nil {
+		return nil, errors.Wrap(err, "statusBus.Subscribe")
+	}
+	return sub, nil
+}
+
+// publishStatusEvent publish email status transition, events are fanned out to watchers of all instances by message broker
+func (e *emailUseCase) publishStatusEvent(eventType string, mail *models.Email) {
+	eventBytes, err := json.Marshal(models.NewStatusEvent(eventType, mail))
+	if err != nil {
+		e.log.Errorf("json.Marshal: %v", err)
+		return
+	}
+	if _, err := e.publisher.PublishAsync(e.cfg.Nats.StatusEvents.Subject, eventBytes, func(_ string, err error) {
+		if err != nil {
+			e.log.Errorf("publish status event: %s: %v", mail.EmailID, err)
+		}
+	}); err != nil {
+		e.log.Errorf("publisher.PublishAsync: %v", err)
+	}
+}
+
+func (e *emailUseCase) cacheEmail(ctx context.Context, mail *models.Email) {
+	if err := e.redisRepo.SetEmail(ctx, mail); err != nil {
+		e.log.Errorf("redisRepo.SetEmail: %v", err)
+	}
+}
+
+func (e *emailUseCase) invalidateCache(ctx context.Context, emailID uuid.UUID) {
+	if err := e.redisRepo.DeleteEmail(ctx, emailID); err != nil {
+		e.log.Errorf("redisRepo.DeleteEmail: %v", err)
+	}
+}
+
+// sendEmailSubject returns send email subject of priority lane
+func (e *emailUseCase) sendEmailSubject(priority string) string {
+	switch priority {
+	case models.PriorityHigh:
+		return e.cfg.Nats.SendEmailHigh.Subject
+	case models.PriorityLow:
+		return e.cfg.Nats.SendEmailLow.Subject
+	default:
+		return e.cfg.Nats.SendEmail.Subject
+	}
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/config"
+	"github.com/AleksK1NG/nats-streaming/internal/email"
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	"github.com/AleksK1NG/nats-streaming/pkg/backoff"
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/AleksK1NG/nats-streaming/pkg/webhook"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/satori/go.uuid"
+)
+
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 500
+	// maxResponseBodyBytes webhook response body is drained up to this size for connection reuse
+	maxResponseBodyBytes = 64 * 1024
+	maxLastErrorLength   = 500
+)
+
+type webhookUseCase struct {
+	log           logger.Logger
+	cfg           *config.Config
+	webhookRepo   email.WebhookRepository
+	httpClient    *http.Client
+	retryPolicy   *backoff.Policy
+	addressPolicy webhook.AddressPolicy
+}
+
+// NewWebhookUseCase webhook usecase constructor
+func NewWebhookUseCase(log logger.Logger, cfg *config.Config, webhookRepo email.WebhookRepository, httpClient *http.Client) *webhookUseCase {
+	return &webhookUseCase{
+		log:           log,
+		cfg:           cfg,
+		webhookRepo:   webhookRepo,
+		httpClient:    httpClient,
+		retryPolicy:   backoff.NewPolicy(cfg.Webhooks.Retry),
+		addressPolicy: webhook.NewAddressPolicy(cfg.Webhooks),
+	}
+}
+
+// Create create webhook, random secret is generated if it is not set
+func (w *webhookUseCase) Create(ctx context.Context, wh *models.Webhook) (*models.Webhook, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookUseCase.Create")
+	defer span.Finish()
+
+	if err := w.addressPolicy.ValidateURL(wh.URL); err != nil {
+		return nil, domainErrors.NewFieldError("url", err)
+	}
+
+	if wh.Secret == "" {
+		secret, err := webhook.GenerateSecret()
+		if err != nil {
+			return nil, errors.Wrap(err, "webhook.GenerateSecret")
+		}
+		wh.Secret = secret
+	}
+
+	return w.webhookRepo.Create(ctx, wh)
+}
+
+// GetByID find webhook by id
+func (w *webhookUseCase) GetByID(ctx context.Context, webhookID uuid.UUID) (*models.Webhook, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookUseCase.GetByID")
+	defer span.Finish()
+
+	return w.webhookRepo.GetByID(ctx, webhookID)
+}
+
+// List get all webhooks
+func (w *webhookUseCase) List(ctx context.Context) (*models.WebhooksList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookUseCase.List")
+	defer span.Finish()
+
+	webhooks, err := w.webhookRepo.List(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookRepo.List")
+	}
+
+	return &models.WebhooksList{Webhooks: webhooks}, nil
+}
+
+// Update update webhook
+func (w *webhookUseCase) Update(ctx context.Context, webhookID uuid.UUID, req *models.UpdateWebhookReq) (*models.Webhook, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookUseCase.Update")
+	defer span.Finish()
+
+	if err := w.addressPolicy.ValidateURL(req.URL); err != nil {
+		return nil, domainErrors.NewFieldError("url", err)
+	}
+
+	return w.webhookRepo.Update(ctx, webhookID, req)
+}
+
+// Delete delete webhook
+func (w *webhookUseCase) Delete(ctx context.Context, webhookID uuid.UUID) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookUseCase.Delete")
+	defer span.Finish()
+
+	return w.webhookRepo.Delete(ctx, webhookID)
+}
+
+// GetDeliveries get last deliveries of webhook, newest first
+func (w *webhookUseCase) GetDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) (*models.WebhookDeliveriesList, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookUseCase.GetDeliveries")
+	defer span.Finish()
+
+	if _, err := w.webhookRepo.GetByID(ctx, webhookID); err != nil {
+		return nil, errors.Wrap(err, "webhookRepo.GetByID")
+	}
+
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+	if limit > maxDeliveriesLimit {
+		limit = maxDeliveriesLimit
+	}
+
+	deliveries, err := w.webhookRepo.GetDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookRepo.GetDeliveries")
+	}
+
+	return &models.WebhookDeliveriesList{WebhookID: webhookID, Deliveries: deliveries}, nil
+}
+
+// Enqueue create deliveries of status event to subscribed webhooks, returns number of created deliveries
+func (w *webhookUseCase) Enqueue(ctx context.Context, event *models.StatusEvent) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookUseCase.Enqueue")
+	defer span.Finish()
+
+	payload, err := json.Marshal(models.NewWebhookPayload(event))
+	if err != nil {
+		return 0, errors.Wrap(err, "json.Marshal")
+	}
+
+	return w.webhookRepo.EnqueueDeliveries(ctx, event, payload)
+}
+
+// DispatchDue claim due deliveries and post them concurrently, failed deliveries are retried with exponential backoff,
+// returns number of attempted deliveries
+func (w *webhookUseCase) DispatchDue(ctx context.Context) (int, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "webhookUseCase.DispatchDue")
+	defer span.Finish()
+
+	// lease outlives request timeout, so claimed delivery is not claimed again while it is in flight
+	lease := 2 * w.cfg.Webhooks.Timeout * time.Second
+	deliveries, err := w.webhookRepo.ClaimDueDeliveries(ctx, w.cfg.Webhooks.Retry.PollBatchSize, lease)
+	if err != nil {
+		return 0, errors.Wrap(err, "webhookRepo.ClaimDueDeliveries")
+	}
+
+	concurrency := w.cfg.Webhooks.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for _, d := range deliveries {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(d *models.WebhookDelivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := w.deliver(ctx, d); err != nil {
+				w.log.Errorf("webhook delivery %s: %v", d.DeliveryID, err)
+			}
+		}(d)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// deliver post delivery payload and record attempt result
+func (w *webhookUseCase) deliver(ctx context.Context, d *models.WebhookDelivery) error {
+	attempts := d.Attempts + 1
+	statusCode, postErr := w.post(ctx, d)
+	if postErr == nil {
+		return w.webhookRepo.SetDelivered(ctx, d.DeliveryID, attempts, statusCode)
+	}
+
+	var nextAttemptAt *time.Time
+	if !w.retryPolicy.Exhausted(attempts, d.CreatedAt) {
+		next := time.Now().UTC().Add(w.retryPolicy.Delay(attempts))
+		nextAttemptAt = &next
+	}
+
+	lastError := postErr.Error()
+	if len(lastError) > maxLastErrorLength {
+		lastError = lastError[:maxLastErrorLength]
+	}
+
+	disabled, err := w.webhookRepo.SetFailed(ctx, d.DeliveryID, attempts, statusCode, lastError, nextAttemptAt, w.cfg.Webhooks.DisableAfterFailures)
+	if err != nil {
+		return errors.Wrap(err, "webhookRepo.SetFailed")
+	}
+	if disabled {
+		w.log.Warnf("webhook %s disabled after %d consecutive failures, last error: %s", d.WebhookID, w.cfg.Webhooks.DisableAfterFailures, lastError)
+	}
+	if nextAttemptAt == nil {
+		w.log.Errorf("webhook delivery %s failed after %d attempts: %s", d.DeliveryID, attempts, lastError)
+	}
+
+	return nil
+}
+
+// post send signed payload to webhook url, returns response status code, non 2xx status is an error
+func (w *webhookUseCase) post(ctx context.Context, d *models.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.cfg.Webhooks.Timeout*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, errors.Wrap(err, "http.NewRequestWithContext")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(d.Secret, time.Now(), d.Payload))
+	req.Header.Set(webhook.EventHeader, d.EventType)
+	req.Header.Set(webhook.DeliveryHeader, d.DeliveryID.String())
+
+	res, err := w.httpClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "httpClient.Do")
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseBodyBytes)) // nolint: errcheck
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return res.StatusCode, fmt.Errorf("unexpected response status: %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/config"
+	"github.com/AleksK1NG/nats-streaming/internal/email"
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/AleksK1NG/nats-streaming/pkg/webhook"
+	uuid "github.com/satori/go.uuid"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+// webhookRepoStub single delivery of single webhook, failures and disabling follow SetFailed query
+type webhookRepoStub struct {
+	email.WebhookRepository
+	mu       sync.Mutex
+	delivery models.WebhookDelivery
+	enabled  bool
+	failures int
+}
+
+func newWebhookRepoStub(url string) *webhookRepoStub {
+	return &webhookRepoStub{
+		enabled: true,
+		delivery: models.WebhookDelivery{
+			DeliveryID: uuid.NewV4(),
+			WebhookID:  uuid.NewV4(),
+			EventType:  models.EventTypeSent,
+			Payload:    []byte(`{"sequence":1,"type":"sent"}`),
+			Status:     models.WebhookDeliveryPending,
+			CreatedAt:  time.Now().UTC(),
+			URL:        url,
+			Secret:     testWebhookSecret,
+		},
+	}
+}
+
+// ClaimDueDeliveries next attempt time is ignored, so tests do not wait for backoff
+func (r *webhookRepoStub) ClaimDueDeliveries(_ context.Context, _ int, _ time.Duration) ([]*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.enabled || r.delivery.Status != models.WebhookDeliveryPending {
+		return nil, nil
+	}
+	d := r.delivery
+	return []*models.WebhookDelivery{&d}, nil
+}
+
+func (r *webhookRepoStub) SetDelivered(_ context.Context, _ uuid.UUID, attempts int, statusCode int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.delivery.Status = models.WebhookDeliveryDelivered
+	r.delivery.Attempts = attempts
+	r.delivery.LastStatusCode = statusCode
+	r.delivery.NextAttemptAt = nil
+	r.failures = 0
+	return nil
+}
+
+func (r *webhookRepoStub) SetFailed(
+	_ context.Context,
+	_ uuid.UUID,
+	attempts int,
+	statusCode int,
+	lastError string,
+	nextAttemptAt *time.Time,
+	disableAfter int,
+) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.delivery.Status = models.WebhookDeliveryPending
+	if nextAttemptAt == nil {
+		r.delivery.Status = models.WebhookDeliveryFailed
+	}
+	r.delivery.Attempts = attempts
+	r.delivery.LastStatusCode = statusCode
+	r.delivery.LastError = lastError
+	r.delivery.NextAttemptAt = nextAttemptAt
+
+	r.failures++
+	disabled := r.enabled && disableAfter > 0 && r.failures >= disableAfter
+	if disabled {
+		r.enabled = false
+	}
+	return disabled, nil
+}
+
+func newTestWebhookUseCase(t *testing.T, repo *webhookRepoStub, client *http.Client, maxAttempts int, disableAfter int) *webhookUseCase {
+	t.Helper()
+	cfg := &config.Config{
+		Logger: config.Logger{Level: "error", Encoding: "console"},
+		Webhooks: config.Webhooks{
+			Timeout:              5,
+			Concurrency:          2,
+			DisableAfterFailures: disableAfter,
+			Retry: config.RetryPolicy{
+				InitialInterval: 10,
+				MaxInterval:     3600,
+				Multiplier:      2,
+				Jitter:          0.2,
+				MaxAttempts:     maxAttempts,
+				PollBatchSize:   10,
+			},
+		},
+	}
+	log := logger.NewApiLogger(cfg)
+	log.InitLogger()
+	return NewWebhookUseCase(log, cfg, repo, client)
+}
+
+func TestDispatchDueDelivered(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("io.ReadAll: %v", err)
+		}
+		if err := webhook.Verify(testWebhookSecret, r.Header.Get(webhook.SignatureHeader), body, time.Minute); err != nil {
+			t.Errorf("webhook.Verify: %v", err)
+		}
+		if r.Header.Get(webhook.EventHeader) != models.EventTypeSent {
+			t.Errorf("event header = %q, want %q", r.Header.Get(webhook.EventHeader), models.EventTypeSent)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	repo := newWebhookRepoStub(srv.URL)
+	uc := newTestWebhookUseCase(t, repo, srv.Client(), 10, 0)
+
+	dispatched, err := uc.DispatchDue(context.Background())
+	if err != nil {
+		t.Fatalf("DispatchDue: %v", err)
+	}
+	if dispatched != 1 {
+		t.Fatalf("dispatched = %d, want 1", dispatched)
+	}
+	if repo.delivery.Status != models.WebhookDeliveryDelivered || repo.delivery.Attempts != 1 || repo.delivery.LastStatusCode != http.StatusNoContent {
+		t.Fatalf("delivery = %+v, want delivered with status code %d after 1 attempt", repo.delivery, http.StatusNoContent)
+	}
+}
+
+func TestDispatchDueRetry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	t.Run("backoff", func(t *testing.T) {
+		repo := newWebhookRepoStub(srv.URL)
+		uc := newTestWebhookUseCase(t, repo, srv.Client(), 10, 0)
+
+		for attempt := 1; attempt <= 2; attempt++ {
+			before := time.Now()
+			if _, err := uc.DispatchDue(context.Background()); err != nil {
+				t.Fatalf("DispatchDue: %v", err)
+			}
+
+			d := repo.delivery
+			if d.Status != models.WebhookDeliveryPending || d.Attempts != attempt || d.LastStatusCode != http.StatusInternalServerError {
+				t.Fatalf("delivery = %+v, want pending with status code %d after %d attempts", d, http.StatusInternalServerError, attempt)
+			}
+			// initial interval 10s doubled per attempt with 20% jitter
+			delay := time.Duration(10<<(attempt-1)) * time.Second
+			minNext, maxNext := before.Add(delay*8/10), time.Now().Add(delay*12/10)
+			if d.NextAttemptAt == nil || d.NextAttemptAt.Before(minNext) || d.NextAttemptAt.After(maxNext) {
+				t.Fatalf("next attempt at = %v, want between %v and %v", d.NextAttemptAt, minNext, maxNext)
+			}
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		repo := newWebhookRepoStub(srv.URL)
+		uc := newTestWebhookUseCase(t, repo, srv.Client(), 1, 0)
+
+		if _, err := uc.DispatchDue(context.Background()); err != nil {
+			t.Fatalf("DispatchDue: %v", err)
+		}
+		if repo.delivery.Status != models.WebhookDeliveryFailed || repo.delivery.NextAttemptAt != nil {
+			t.Fatalf("delivery = %+v, want failed without next attempt", repo.delivery)
+		}
+	})
+}
+
+func TestDispatchDueDisablesWebhook(t *testing.T) {
+	const disableAfter = 3
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	repo := newWebhookRepoStub(srv.URL)
+	uc := newTestWebhookUseCase(t, repo, srv.Client(), 0, disableAfter)
+
+	for i := 0; i < disableAfter+2; i++ {
+		if _, err := uc.DispatchDue(context.Background()); err != nil {
+			t.Fatalf("DispatchDue: %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&requests); got != disableAfter {
+		t.Fatalf("requests = %d, want %d", got, disableAfter)
+	}
+	if repo.enabled {
+		t.Fatalf("webhook is enabled after %d consecutive failures", disableAfter)
+	}
+}
+
+func TestDispatchDueRedirectIsFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	repo := newWebhookRepoStub(srv.URL)
+	client := webhook.NewHTTPClient(time.Second, webhook.AddressPolicy{AllowHTTP: true, AllowPrivateNetworks: true})
+	uc := newTestWebhookUseCase(t, repo, client, 10, 0)
+
+	if _, err := uc.DispatchDue(context.Background()); err != nil {
+		t.Fatalf("DispatchDue: %v", err)
+	}
+	if repo.delivery.Status != models.WebhookDeliveryPending || repo.delivery.LastStatusCode != http.StatusFound {
+		t.Fatalf("delivery = %+v, want pending with status code %d", repo.delivery, http.StatusFound)
+	}
+}
+
+func TestCreateRejectsForbiddenURL(t *testing.T) {
+	uc := newTestWebhookUseCase(t, newWebhookRepoStub(""), http.DefaultClient, 10, 0)
+
+	for _, url := range []string{"http://hooks.example.com", "https://127.0.0.1:8080", "https://169.254.169.254/latest/meta-data"} {
+		_, err := uc.Create(context.Background(), &models.Webhook{URL: url})
+		if domainErr := domainErrors.From(err); domainErr == nil || domainErr.Kind != domainErrors.InvalidArgument {
+			t.Fatalf("Create(%s) error = %v, want invalid argument", url, err)
+		}
+	}
+}
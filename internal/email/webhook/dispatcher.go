@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/config"
+	"github.com/AleksK1NG/nats-streaming/internal/email"
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/nats-io/stan.go"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+type dispatcher struct {
+	log       logger.Logger
+	cfg       *config.Config
+	stanConn  stan.Conn
+	webhookUC email.WebhookUseCase
+}
+
+// NewDispatcher webhooks dispatcher constructor
+func NewDispatcher(log logger.Logger, cfg *config.Config, stanConn stan.Conn, webhookUC email.WebhookUseCase) *dispatcher {
+	return &dispatcher{log: log, cfg: cfg, stanConn: stanConn, webhookUC: webhookUC}
+}
+
+// Run enqueue status events to webhooks and post due deliveries every poll interval until context is done,
+// events are consumed by durable queue group, so each event is enqueued by single replica
+func (d *dispatcher) Run(ctx context.Context) error {
+	sub, err := d.stanConn.QueueSubscribe(
+		d.cfg.Nats.StatusEvents.Subject,
+		d.cfg.Webhooks.QueueGroup,
+		d.enqueueHandler(ctx),
+		stan.SetManualAckMode(),
+		stan.AckWait(d.cfg.Webhooks.AckWait*time.Second),
+		stan.DurableName(d.cfg.Webhooks.DurableName),
+		stan.MaxInflight(d.cfg.Webhooks.MaxInflight),
+		stan.StartAtTimeDelta(d.cfg.Nats.StatusEvents.ReplayWindow*time.Second),
+	)
+	if err != nil {
+		return errors.Wrap(err, "QueueSubscribe")
+	}
+	// Close instead of Unsubscribe keeps durable queue group position for restart
+	defer sub.Close() // nolint: errcheck
+
+	ticker := time.NewTicker(d.cfg.Webhooks.Retry.PollInterval * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			dispatched, err := d.webhookUC.DispatchDue(ctx)
+			if err != nil {
+				dispatchErrors.Inc()
+				d.log.Errorf("webhookUC.DispatchDue: %v", err)
+			}
+			dispatchedDeliveries.Add(float64(dispatched))
+		}
+	}
+}
+
+// enqueueHandler message is not acked if deliveries are not saved, so it is redelivered
+func (d *dispatcher) enqueueHandler(ctx context.Context) stan.MsgHandler {
+	return func(msg *stan.Msg) {
+		span, ctx := opentracing.StartSpanFromContext(ctx, "webhookDispatcher.enqueue")
+		defer span.Finish()
+
+		var event models.StatusEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			d.log.Errorf("json.Unmarshal : %v", err)
+			if err := msg.Ack(); err != nil {
+				d.log.Errorf("msg.Ack: %v", err)
+			}
+			return
+		}
+		event.Sequence = msg.Sequence
+
+		enqueued, err := d.webhookUC.Enqueue(ctx, &event)
+		if err != nil {
+			enqueueErrors.Inc()
+			d.log.Errorf("webhookUC.Enqueue: %v", err)
+			return
+		}
+		enqueuedDeliveries.Add(float64(enqueued))
+
+		if err := msg.Ack(); err != nil {
+			d.log.Errorf("msg.Ack: %v", err)
+		}
+	}
+}
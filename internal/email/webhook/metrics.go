@@ -0,0 +1,25 @@
+package webhook
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	enqueuedDeliveries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "webhooks_enqueued_deliveries_total",
+		Help: "The total number of webhook deliveries created from status events",
+	})
+	enqueueErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "webhooks_enqueue_errors_total",
+		Help: "The total number of status events failed to enqueue to webhooks",
+	})
+	dispatchedDeliveries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "webhooks_dispatched_deliveries_total",
+		Help: "The total number of attempted webhook deliveries",
+	})
+	dispatchErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "webhooks_dispatch_errors_total",
+		Help: "The total number of failed webhook dispatch runs",
+	})
+)
@@ -45,3 +45,19 @@ func (im *interceptorManager) Logger(
 
 	return reply, err
 }
+
+// StreamLogger Interceptor
+func (im *interceptorManager) StreamLogger(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	totalRequests.Inc()
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	err := handler(srv, stream)
+	im.logger.Infof("Stream: %s, Time: %v, Metadata: %v, Err: %v", info.FullMethod, time.Since(start), md, err)
+
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/AleksK1NG/nats-streaming/config"
 	"github.com/AleksK1NG/nats-streaming/pkg/logger"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -24,6 +25,7 @@ type middlewareManager struct {
 // MiddlewareManager interface
 type MiddlewareManager interface {
 	Metrics(next echo.HandlerFunc) echo.HandlerFunc
+	WriteDeadline(streaming middleware.Skipper) echo.MiddlewareFunc
 }
 
 // NewMiddlewareManager constructor
@@ -0,0 +1,54 @@
+package middlewares
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type connContextKey struct{}
+
+// ConnContext http server ConnContext hook, keeps connection in request context for per route write deadline
+func ConnContext(ctx context.Context, c net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, c)
+}
+
+// WriteDeadline write timeout of http server per route, long lived streams matched by streaming are served without it,
+// http/2 streams share connection, so their handler context deadline is set instead of connection deadline
+func (m *middlewareManager) WriteDeadline(streaming middleware.Skipper) echo.MiddlewareFunc {
+	timeout := m.cfg.HTTP.WriteTimeout * time.Second
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			isStreaming := streaming(c)
+
+			if conn, ok := req.Context().Value(connContextKey{}).(net.Conn); ok && req.ProtoMajor == 1 {
+				var deadline time.Time
+				if !isStreaming && timeout > 0 {
+					deadline = time.Now().Add(timeout)
+				}
+				if err := conn.SetWriteDeadline(deadline); err != nil {
+					m.log.Errorf("conn.SetWriteDeadline: %v", err)
+				}
+				// connection read deadline left from reading request would cancel stream context on expiration
+				if isStreaming {
+					if err := conn.SetReadDeadline(time.Time{}); err != nil {
+						m.log.Errorf("conn.SetReadDeadline: %v", err)
+					}
+				}
+				return next(c)
+			}
+
+			if isStreaming || timeout <= 0 {
+				return next(c)
+			}
+			ctx, cancel := context.WithTimeout(req.Context(), timeout)
+			defer cancel()
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	emailService "github.com/AleksK1NG/nats-streaming/proto/email"
+	uuid "github.com/satori/go.uuid"
+)
+
+// MaxBatchSize max number of emails in single batch create request
+const MaxBatchSize = 1000
+
+// CreateBatchReq batch create emails request, emails are validated one by one
+type CreateBatchReq struct {
+	Emails []*Email `json:"emails" validate:"required,min=1,max=1000"`
+}
+
+// BatchItemResult result of single batch item, EmailID is set if email is created
+type BatchItemResult struct {
+	Index   int        `json:"index"`
+	EmailID *uuid.UUID `json:"emailID,omitempty"`
+	Error   string     `json:"error,omitempty"`
+}
+
+// BatchResult batch create emails result with item results in request order
+type BatchResult struct {
+	Created int                `json:"created"`
+	Failed  int                `json:"failed"`
+	Items   []*BatchItemResult `json:"items"`
+}
+
+// NewBatchResult batch result constructor
+func NewBatchResult(size int) *BatchResult {
+	items := make([]*BatchItemResult, size)
+	for i := range items {
+		items[i] = &BatchItemResult{Index: i}
+	}
+	return &BatchResult{Items: items}
+}
+
+// SetCreated mark item as created
+func (r *BatchResult) SetCreated(index int, emailID uuid.UUID) {
+	r.Items[index].EmailID = &emailID
+	r.Created++
+}
+
+// SetFailed mark item as failed
+func (r *BatchResult) SetFailed(index int, err error) {
+	r.Items[index].Error = err.Error()
+	r.Failed++
+}
+
+// ToProto convert batch result to proto
+func (r *BatchResult) ToProto() *emailService.CreateBatchRes {
+	items := make([]*emailService.BatchItemResult, 0, len(r.Items))
+	for _, item := range r.Items {
+		res := &emailService.BatchItemResult{Index: int64(item.Index), Error: item.Error}
+		if item.EmailID != nil {
+			res.EmailID = item.EmailID.String()
+		}
+		items = append(items, res)
+	}
+	return &emailService.CreateBatchRes{Created: int64(r.Created), Failed: int64(r.Failed), Items: items}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	emailService "github.com/AleksK1NG/nats-streaming/proto/email"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	AttemptStatusSent   = "sent"
+	AttemptStatusFailed = "failed"
+)
+
+// DeliveryAttempt single SMTP send attempt of email
+type DeliveryAttempt struct {
+	AttemptID  uuid.UUID      `json:"attemptID"`
+	EmailID    uuid.UUID      `json:"emailID"`
+	Attempt    int            `json:"attempt"`
+	WorkerID   string         `json:"workerID"`
+	Relay      string         `json:"relay"`
+	Status     string         `json:"status"`
+	StartedAt  time.Time      `json:"startedAt"`
+	FinishedAt time.Time      `json:"finishedAt"`
+	Error      *DeliveryError `json:"error,omitempty"`
+}
+
+// DeliveryHistory email delivery attempts response
+type DeliveryHistory struct {
+	EmailID  uuid.UUID          `json:"emailID"`
+	Attempts []*DeliveryAttempt `json:"attempts"`
+}
+
+// ToProto convert delivery attempt to proto
+func (a *DeliveryAttempt) ToProto() *emailService.DeliveryAttempt {
+	return &emailService.DeliveryAttempt{
+		AttemptID:  a.AttemptID.String(),
+		EmailID:    a.EmailID.String(),
+		Attempt:    int64(a.Attempt),
+		WorkerID:   a.WorkerID,
+		Relay:      a.Relay,
+		Status:     a.Status,
+		StartedAt:  timestamppb.New(a.StartedAt),
+		FinishedAt: timestamppb.New(a.FinishedAt),
+		Error:      a.Error.ToProto(),
+	}
+}
+
+// ToProto convert delivery history to proto
+func (h *DeliveryHistory) ToProto() []*emailService.DeliveryAttempt {
+	attempts := make([]*emailService.DeliveryAttempt, 0, len(h.Attempts))
+	for _, a := range h.Attempts {
+		attempts = append(attempts, a.ToProto())
+	}
+	return attempts
+}
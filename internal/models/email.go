@@ -3,19 +3,100 @@ package models
 import (
 	"time"
 
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
 	emailService "github.com/AleksK1NG/nats-streaming/proto/email"
 	uuid "github.com/satori/go.uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	PriorityHigh   = "high"
+	PriorityNormal = "normal"
+	PriorityLow    = "low"
+
+	EmailStatusQueued   = "queued"
+	EmailStatusRetrying = "retrying"
+	EmailStatusSent     = "sent"
+	EmailStatusFailed   = "failed"
+
+	DefaultLanguage = "english"
+)
+
+var (
+	ErrEmailNotEditable = domainErrors.New(domainErrors.FailedPrecondition, "Email is sending or already sent")
+	ErrEmptyEmailUpdate = domainErrors.New(domainErrors.InvalidArgument, "At least one of to, subject or message is required")
+)
+
 // Email model
 type Email struct {
-	EmailID   uuid.UUID `json:"emailID"`
-	From      string    `json:"from" validate:"required,min=3,max=60"`
-	To        string    `json:"to" validate:"required,min=3,max=60"`
-	Subject   string    `json:"subject" validate:"required,min=3,max=80"`
-	Message   string    `json:"message" validate:"required,min=3,max=250"`
-	CreatedAt time.Time `json:"createdAt"`
+	EmailID       uuid.UUID      `json:"emailID"`
+	TenantID      string         `json:"tenantID,omitempty" validate:"max=100"`
+	From          string         `json:"from" validate:"required,min=3,max=60"`
+	To            string         `json:"to" validate:"required,min=3,max=60"`
+	Subject       string         `json:"subject" validate:"required,min=3,max=80"`
+	Message       string         `json:"message" validate:"required,min=3,max=250"`
+	Priority      string         `json:"priority" validate:"omitempty,oneof=high normal low"`
+	Language      string         `json:"language" validate:"omitempty,language"`
+	Status        string         `json:"status"`
+	Attempts      int            `json:"attempts"`
+	NextAttemptAt *time.Time     `json:"nextAttemptAt,omitempty"`
+	LastError     *DeliveryError `json:"lastError,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+	Version       int            `json:"version"`
+	Rank          float32        `json:"rank,omitempty"`
+	Snippet       string         `json:"snippet,omitempty"` // html escaped message fragment, matched terms are wrapped by highlight selectors
+}
+
+// GetPriority returns email priority, normal by default
+func (e *Email) GetPriority() string {
+	if e.Priority == "" {
+		return PriorityNormal
+	}
+	return e.Priority
+}
+
+// GetLanguage returns postgresql text search configuration of email, english by default
+func (e *Email) GetLanguage() string {
+	if e.Language == "" {
+		return DefaultLanguage
+	}
+	return e.Language
+}
+
+// UpdateEmailReq update email request, only set fields are changed,
+// Version must be equal to current version of email
+type UpdateEmailReq struct {
+	Version int     `json:"version" validate:"required,min=1"`
+	To      *string `json:"to,omitempty" validate:"omitempty,min=3,max=60"`
+	Subject *string `json:"subject,omitempty" validate:"omitempty,min=3,max=80"`
+	Message *string `json:"message,omitempty" validate:"omitempty,min=3,max=250"`
+}
+
+// Empty returns true if no field is changed
+func (r *UpdateEmailReq) Empty() bool {
+	return r.To == nil && r.Subject == nil && r.Message == nil
+}
+
+// DeliveryError classified error of last failed send attempt
+type DeliveryError struct {
+	Class        string `json:"class"`
+	Code         int    `json:"code,omitempty"`
+	EnhancedCode string `json:"enhancedCode,omitempty"`
+	Message      string `json:"message"`
+}
+
+// ToProto convert delivery error to proto
+func (d *DeliveryError) ToProto() *emailService.DeliveryError {
+	if d == nil {
+		return nil
+	}
+	return &emailService.DeliveryError{
+		Class:        d.Class,
+		Code:         int64(d.Code),
+		EnhancedCode: d.EnhancedCode,
+		Message:      d.Message,
+	}
 }
 
 // EmailsList emails list response with pagination
@@ -25,6 +106,7 @@ type EmailsList struct {
 	Page       int64    `json:"page"`
 	Size       int64    `json:"size"`
 	HasMore    bool     `json:"hasMore"`
+	NextCursor string   `json:"nextCursor,omitempty"`
 	Emails     []*Email `json:"emails"`
 }
 
@@ -32,11 +114,20 @@ type EmailsList struct {
 func (e *Email) ToProto() *emailService.Email {
 	return &emailService.Email{
 		EmailID:   e.EmailID.String(),
+		TenantID:  e.TenantID,
 		From:      e.From,
 		To:        e.To,
 		Subject:   e.Subject,
 		Message:   e.Subject,
+		Priority:  e.GetPriority(),
+		Language:  e.GetLanguage(),
+		Status:    e.Status,
+		Attempts:  int64(e.Attempts),
+		LastError: e.LastError.ToProto(),
 		CreatedAt: timestamppb.New(e.CreatedAt),
+		Rank:      e.Rank,
+		Snippet:   e.Snippet,
+		Version:   int64(e.Version),
 	}
 }
 
@@ -59,10 +150,11 @@ type MailData struct {
 
 // EmailErrorMsg error message dto dead letter queue
 type EmailErrorMsg struct {
-	Subject   string    `json:"subject"`
-	Sequence  uint64    `json:"sequence"`
-	Data      []byte    `json:"data"`
-	Timestamp int64     `json:"topic"`
-	Error     string    `json:"error"`
-	Time      time.Time `json:"time"`
+	Subject       string         `json:"subject"`
+	Sequence      uint64         `json:"sequence"`
+	Data          []byte         `json:"data"`
+	Timestamp     int64          `json:"topic"`
+	Error         string         `json:"error"`
+	DeliveryError *DeliveryError `json:"deliveryError,omitempty"`
+	Time          time.Time      `json:"time"`
 }
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	emailService "github.com/AleksK1NG/nats-streaming/proto/email"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	ErasureModeDelete = "delete"
+	ErasureModeRedact = "redact"
+
+	RedactedAddress = "redacted@invalid"
+	RedactedText    = "[redacted]"
+)
+
+// ErasureReq data subject erasure request, delete mode removes emails,
+// redact mode replaces personal data and soft deletes emails keeping delivery statistics
+type ErasureReq struct {
+	Address string `json:"address" validate:"required,min=3,max=250"`
+	Mode    string `json:"mode" validate:"omitempty,oneof=delete redact"`
+}
+
+// GetMode returns erasure mode, delete by default
+func (r *ErasureReq) GetMode() string {
+	if r.Mode == "" {
+		return ErasureModeDelete
+	}
+	return r.Mode
+}
+
+// ErasureReport result of data subject erasure
+type ErasureReport struct {
+	ErasureID    uuid.UUID   `json:"erasureID"`
+	Mode         string      `json:"mode"`
+	EmailIDs     []uuid.UUID `json:"emailIDs"`
+	Emails       int64       `json:"emails"`
+	Attempts     int64       `json:"attempts"`
+	DeadLetters  int64       `json:"deadLetters"`
+	CacheEvicted int64       `json:"cacheEvicted"`
+	ErasedAt     time.Time   `json:"erasedAt"`
+}
+
+// ToProto convert erasure report to proto
+func (r *ErasureReport) ToProto() *emailService.EraseRes {
+	emailIDs := make([]string, 0, len(r.EmailIDs))
+	for _, id := range r.EmailIDs {
+		emailIDs = append(emailIDs, id.String())
+	}
+	return &emailService.EraseRes{
+		ErasureID:    r.ErasureID.String(),
+		Mode:         r.Mode,
+		EmailIDs:     emailIDs,
+		Emails:       r.Emails,
+		Attempts:     r.Attempts,
+		DeadLetters:  r.DeadLetters,
+		CacheEvicted: r.CacheEvicted,
+		ErasedAt:     timestamppb.New(r.ErasedAt),
+	}
+}
+
+// HashAddress returns hex sha256 of normalized address, audit entries never store address itself
+func HashAddress(address string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(address))))
+	return hex.EncodeToString(sum[:])
+}
+
+// DeadLetter persisted dead letter queue message
+type DeadLetter struct {
+	DeadLetterID uuid.UUID  `json:"deadLetterID"`
+	EmailID      *uuid.UUID `json:"emailID,omitempty"`
+	From         string     `json:"from"`
+	To           string     `json:"to"`
+	Subject      string     `json:"subject"`
+	Sequence     uint64     `json:"sequence"`
+	Data         []byte     `json:"data"`
+	Error        string     `json:"error"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
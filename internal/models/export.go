@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	ExportFormatCSV   = "csv"
+	ExportFormatJSONL = "jsonl"
+
+	exportFileTimeLayout = "20060102T150405Z"
+)
+
+var ErrInvalidExportFormat = domainErrors.New(domainErrors.InvalidArgument, "Invalid export format")
+
+// ExportQuery export of emails matching search query, pagination of search query is ignored
+type ExportQuery struct {
+	Search *SearchQuery
+	Format string
+	Gzip   bool
+}
+
+// NewExportQuery export query constructor, format is csv by default
+func NewExportQuery(search *SearchQuery, format string, gzip bool) (*ExportQuery, error) {
+	switch format {
+	case "":
+		format = ExportFormatCSV
+	case ExportFormatCSV, ExportFormatJSONL:
+	default:
+		return nil, errors.Wrapf(ErrInvalidExportFormat, "unsupported format: %s", format)
+	}
+	return &ExportQuery{Search: search, Format: format, Gzip: gzip}, nil
+}
+
+// ContentType returns media type of export file
+func (q *ExportQuery) ContentType() string {
+	if q.Gzip {
+		return "application/gzip"
+	}
+	if q.Format == ExportFormatJSONL {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// FileName returns name of export file created at given time, e.g. emails-20210301T000000Z.csv.gz
+func (q *ExportQuery) FileName(createdAt time.Time) string {
+	name := fmt.Sprintf("emails-%s.%s", createdAt.UTC().Format(exportFileTimeLayout), q.Format)
+	if q.Gzip {
+		return name + ".gz"
+	}
+	return name
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	emailsPartitionPrefix = "emails_p"
+	emailsPartitionLayout = "200601"
+)
+
+// EmailsPartition monthly emails table partition, From is inclusive and To is exclusive bound in UTC
+type EmailsPartition struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+// NewEmailsPartition returns partition of calendar month containing given time
+func NewEmailsPartition(t time.Time) *EmailsPartition {
+	t = t.UTC()
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return &EmailsPartition{
+		Name: emailsPartitionPrefix + from.Format(emailsPartitionLayout),
+		From: from,
+		To:   from.AddDate(0, 1, 0),
+	}
+}
+
+// ParseEmailsPartition parse partition bounds from partition table name
+func ParseEmailsPartition(name string) (*EmailsPartition, error) {
+	if !strings.HasPrefix(name, emailsPartitionPrefix) {
+		return nil, errors.Errorf("invalid emails partition name: %s", name)
+	}
+	month, err := time.Parse(emailsPartitionLayout, strings.TrimPrefix(name, emailsPartitionPrefix))
+	if err != nil {
+		return nil, errors.Wrap(err, "time.Parse")
+	}
+	return NewEmailsPartition(month), nil
+}
@@ -0,0 +1,300 @@
+package models
+
+import (
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	"github.com/AleksK1NG/nats-streaming/pkg/tsquery"
+	"github.com/AleksK1NG/nats-streaming/pkg/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	OrderByCreatedAt = "createdAt"
+	OrderByUpdatedAt = "updatedAt"
+	OrderBySubject   = "subject"
+	OrderByFrom      = "from"
+	OrderByTo        = "to"
+	OrderByStatus    = "status"
+	OrderByPriority  = "priority"
+	OrderByRank      = "rank"
+
+	orderAsc  = "asc"
+	orderDesc = "desc"
+)
+
+var ErrInvalidSearchQuery = domainErrors.New(domainErrors.InvalidArgument, "Invalid search query")
+
+// searchOrderFields whitelisted search sort fields
+var searchOrderFields = map[string]bool{
+	OrderByCreatedAt: true,
+	OrderByUpdatedAt: true,
+	OrderBySubject:   true,
+	OrderByFrom:      true,
+	OrderByTo:        true,
+	OrderByStatus:    true,
+	OrderByPriority:  true,
+	OrderByRank:      true,
+}
+
+// languages postgresql built in text search configurations
+var languages = map[string]bool{
+	"simple":     true,
+	"arabic":     true,
+	"danish":     true,
+	"dutch":      true,
+	"english":    true,
+	"finnish":    true,
+	"french":     true,
+	"german":     true,
+	"greek":      true,
+	"hungarian":  true,
+	"indonesian": true,
+	"irish":      true,
+	"italian":    true,
+	"lithuanian": true,
+	"nepali":     true,
+	"norwegian":  true,
+	"portuguese": true,
+	"romanian":   true,
+	"russian":    true,
+	"spanish":    true,
+	"swedish":    true,
+	"tamil":      true,
+	"turkish":    true,
+}
+
+var emailStatuses = map[string]bool{
+	EmailStatusQueued:   true,
+	EmailStatusRetrying: true,
+	EmailStatusSent:     true,
+	EmailStatusFailed:   true,
+}
+
+// SearchQuery emails search params, keyset pagination is used if Cursor is set, offset pagination otherwise
+type SearchQuery struct {
+	Search         string
+	TSQuery        string
+	Language       string
+	Filter         SearchFilter
+	Order          SearchOrder
+	Highlight      HighlightOptions
+	Pagination     *utils.Pagination
+	Cursor         *SearchCursor
+	SkipTotalCount bool
+}
+
+// SearchFilter structured search filters, empty values are ignored
+type SearchFilter struct {
+	From          string
+	To            string
+	Status        string
+	SubjectPrefix string
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+}
+
+// SearchOrder whitelisted sort field with direction, ties are broken by email id
+type SearchOrder struct {
+	Field string
+	Desc  bool
+}
+
+// snippet markers wrap matched terms in snippets highlighted by db, they are not valid in html,
+// so they can't be confused with message text
+const (
+	SnippetStartMarker = "\x02"
+	SnippetStopMarker  = "\x03"
+
+	defaultHighlightStartSel = "<b>"
+	defaultHighlightStopSel  = "</b>"
+)
+
+// HighlightOptions search snippet options, StartSel and StopSel wrap matched terms, default is <b> and </b>
+type HighlightOptions struct {
+	StartSel     string
+	StopSel      string
+	MaxWords     int
+	MinWords     int
+	MaxFragments int
+}
+
+// FormatSnippet html escape snippet highlighted by db with snippet markers and replace markers with StartSel
+// and StopSel, so message text of snippet is safe to render as html
+func (h HighlightOptions) FormatSnippet(snippet string) string {
+	startSel, stopSel := h.StartSel, h.StopSel
+	if startSel == "" {
+		startSel = defaultHighlightStartSel
+	}
+	if stopSel == "" {
+		stopSel = defaultHighlightStopSel
+	}
+	return strings.NewReplacer(SnippetStartMarker, startSel, SnippetStopMarker, stopSel).Replace(html.EscapeString(snippet))
+}
+
+// SearchCursor keyset pagination position of last returned email
+type SearchCursor struct {
+	Order   string    `json:"o"`
+	Value   string    `json:"v"`
+	EmailID uuid.UUID `json:"i"`
+}
+
+// NewSearchQuery search query constructor, search text is compiled to tsquery using optional language hint,
+// sort order is parsed from pagination OrderBy
+// and cursor is opaque value of SearchCursor returned by previous search with the same order
+func NewSearchQuery(
+	search string,
+	language string,
+	filter SearchFilter,
+	pagination *utils.Pagination,
+	cursor string,
+	skipTotalCount bool,
+) (*SearchQuery, error) {
+	order, err := ParseSearchOrder(pagination.GetOrderBy())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	search = strings.TrimSpace(search)
+	tsQuery, err := tsquery.Parse(search)
+	if err != nil {
+		return nil, err
+	}
+	if language != "" && !SupportedLanguage(language) {
+		return nil, errors.Wrapf(ErrInvalidSearchQuery, "unsupported language: %s", language)
+	}
+	if order.Field == OrderByRank && tsQuery == "" {
+		return nil, errors.Wrap(ErrInvalidSearchQuery, "orderBy rank requires search text")
+	}
+
+	q := &SearchQuery{
+		Search:         search,
+		TSQuery:        tsQuery,
+		Language:       language,
+		Filter:         filter,
+		Order:          *order,
+		Pagination:     pagination,
+		SkipTotalCount: skipTotalCount,
+	}
+	if cursor == "" {
+		return q, nil
+	}
+
+	var c SearchCursor
+	if err := utils.DecodeCursor(cursor, &c); err != nil {
+		return nil, err
+	}
+	if c.Order != order.String() {
+		return nil, errors.Wrapf(utils.ErrInvalidCursor, "cursor order: %s, query order: %s", c.Order, order.String())
+	}
+	q.Cursor = &c
+
+	return q, nil
+}
+
+// ParseSearchOrder parse whitelisted sort order in form field[:asc|desc], default is createdAt ascending,
+// rank is sorted descending by default so the most relevant emails come first
+func ParseSearchOrder(orderBy string) (*SearchOrder, error) {
+	if orderBy == "" {
+		return &SearchOrder{Field: OrderByCreatedAt}, nil
+	}
+
+	field, direction := orderBy, orderAsc
+	if orderBy == OrderByRank {
+		direction = orderDesc
+	}
+	if i := strings.LastIndex(orderBy, ":"); i >= 0 {
+		field, direction = orderBy[:i], strings.ToLower(orderBy[i+1:])
+	}
+
+	if !searchOrderFields[field] {
+		return nil, errors.Wrapf(ErrInvalidSearchQuery, "unsupported orderBy field: %s", field)
+	}
+	if direction != orderAsc && direction != orderDesc {
+		return nil, errors.Wrapf(ErrInvalidSearchQuery, "unsupported orderBy direction: %s", direction)
+	}
+
+	return &SearchOrder{Field: field, Desc: direction == orderDesc}, nil
+}
+
+func (o SearchOrder) String() string {
+	if o.Desc {
+		return o.Field + ":" + orderDesc
+	}
+	return o.Field + ":" + orderAsc
+}
+
+// Validate validate filter values
+func (f *SearchFilter) Validate() error {
+	if f.Status != "" && !emailStatuses[f.Status] {
+		return errors.Wrapf(ErrInvalidSearchQuery, "unsupported status: %s", f.Status)
+	}
+	if f.CreatedFrom != nil && f.CreatedTo != nil && f.CreatedFrom.After(*f.CreatedTo) {
+		return errors.Wrap(ErrInvalidSearchQuery, "createdFrom is after createdTo")
+	}
+	return nil
+}
+
+// NewSearchCursor returns cursor positioned after given email in given sort order
+func NewSearchCursor(email *Email, order SearchOrder) *SearchCursor {
+	return &SearchCursor{Order: order.String(), Value: email.orderValue(order.Field), EmailID: email.EmailID}
+}
+
+// Encode encode cursor to opaque string
+func (c *SearchCursor) Encode() (string, error) {
+	return utils.EncodeCursor(c)
+}
+
+func (e *Email) orderValue(field string) string {
+	switch field {
+	case OrderByUpdatedAt:
+		return e.UpdatedAt.Format(time.RFC3339Nano)
+	case OrderBySubject:
+		return e.Subject
+	case OrderByFrom:
+		return e.From
+	case OrderByTo:
+		return e.To
+	case OrderByStatus:
+		return e.Status
+	case OrderByPriority:
+		return e.GetPriority()
+	case OrderByRank:
+		return strconv.FormatFloat(float64(e.Rank), 'g', -1, 32)
+	default:
+		return e.CreatedAt.Format(time.RFC3339Nano)
+	}
+}
+
+// SupportedLanguage check if language is supported postgresql text search configuration
+func SupportedLanguage(language string) bool {
+	return languages[language]
+}
+
+// Languages returns sorted supported postgresql text search configurations
+func Languages() []string {
+	list := make([]string, 0, len(languages))
+	for language := range languages {
+		list = append(list, language)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// RegisterValidations register custom validation tags of models,
+// language tag accepts supported postgresql text search configurations
+func RegisterValidations(validate *validator.Validate) error {
+	return validate.RegisterValidation("language", func(fl validator.FieldLevel) bool {
+		return SupportedLanguage(fl.Field().String())
+	})
+}
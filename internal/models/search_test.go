@@ -0,0 +1,37 @@
+package models
+
+import "testing"
+
+func TestHighlightOptionsFormatSnippet(t *testing.T) {
+	tests := []struct {
+		name    string
+		options HighlightOptions
+		snippet string
+		want    string
+	}{
+		{
+			name:    "default selectors",
+			snippet: "your \x02invoice\x03 is ready",
+			want:    "your <b>invoice</b> is ready",
+		},
+		{
+			name:    "configured selectors",
+			options: HighlightOptions{StartSel: "<mark>", StopSel: "</mark>"},
+			snippet: "\x02invoice\x03 & \x02receipt\x03",
+			want:    "<mark>invoice</mark> &amp; <mark>receipt</mark>",
+		},
+		{
+			name:    "message html is escaped",
+			snippet: "<script>alert(\"x\")</script> \x02invoice\x03 <b>bold</b>",
+			want:    `&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; <b>invoice</b> &lt;b&gt;bold&lt;/b&gt;`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.options.FormatSnippet(tt.snippet); got != tt.want {
+				t.Errorf("FormatSnippet(%q) = %q, want %q", tt.snippet, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"time"
+
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	emailService "github.com/AleksK1NG/nats-streaming/proto/email"
+	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Status event types
+const (
+	EventTypeCreated      = "created"
+	EventTypeQueued       = "queued"
+	EventTypeSent         = "sent"
+	EventTypeRetrying     = "retrying"
+	EventTypeFailed       = "failed"
+	EventTypeDeadLettered = "dead_lettered"
+	EventTypeSnapshot     = "snapshot"
+)
+
+var eventTypes = map[string]struct{}{
+	EventTypeCreated:      {},
+	EventTypeQueued:       {},
+	EventTypeSent:         {},
+	EventTypeRetrying:     {},
+	EventTypeFailed:       {},
+	EventTypeDeadLettered: {},
+}
+
+var (
+	ErrResumeExpired = domainErrors.New(domainErrors.OutOfRange, "Resume sequence is no longer buffered")
+	// ErrSlowConsumer consumer may reconnect and resume after last received sequence
+	ErrSlowConsumer = &domainErrors.Error{Kind: domainErrors.ResourceExhausted, Message: "Status events consumer is too slow", RetryAfter: time.Second}
+
+	ErrInvalidStatusFilter = domainErrors.New(domainErrors.InvalidArgument, "Invalid status filter")
+)
+
+// StatusEvent email status transition, Sequence is assigned by message broker and used to resume watching
+type StatusEvent struct {
+	Sequence   uint64         `json:"sequence"`
+	Type       string         `json:"type"`
+	EmailID    uuid.UUID      `json:"emailID"`
+	TenantID   string         `json:"tenantID,omitempty"`
+	From       string         `json:"from"`
+	To         string         `json:"to"`
+	Status     string         `json:"status"`
+	Attempts   int            `json:"attempts"`
+	LastError  *DeliveryError `json:"lastError,omitempty"`
+	OccurredAt time.Time      `json:"occurredAt"`
+}
+
+// NewStatusEvent returns status event of given type with current email state
+func NewStatusEvent(eventType string, email *Email) *StatusEvent {
+	return &StatusEvent{
+		Type:       eventType,
+		EmailID:    email.EmailID,
+		TenantID:   email.TenantID,
+		From:       email.From,
+		To:         email.To,
+		Status:     email.Status,
+		Attempts:   email.Attempts,
+		LastError:  email.LastError,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// ToProto convert status event to proto
+func (e *StatusEvent) ToProto() *emailService.StatusEvent {
+	return &emailService.StatusEvent{
+		Sequence:   e.Sequence,
+		EmailID:    e.EmailID.String(),
+		TenantID:   e.TenantID,
+		From:       e.From,
+		To:         e.To,
+		Status:     e.Status,
+		Attempts:   int64(e.Attempts),
+		LastError:  e.LastError.ToProto(),
+		OccurredAt: timestamppb.New(e.OccurredAt),
+		Type:       e.Type,
+	}
+}
+
+// StatusFilter status events filter, empty fields match all events
+type StatusFilter struct {
+	EmailID  uuid.UUID
+	From     string
+	TenantID string
+	Types    []string
+}
+
+// NewStatusFilter parse status filter, empty values are not filtered
+func NewStatusFilter(emailID, from, tenantID string, types []string) (StatusFilter, error) {
+	filter := StatusFilter{From: from, TenantID: tenantID}
+	if emailID != "" {
+		emailUUID, err := uuid.FromString(emailID)
+		if err != nil {
+			return StatusFilter{}, errors.Wrapf(ErrInvalidStatusFilter, "emailID: %v", err)
+		}
+		filter.EmailID = emailUUID
+	}
+	for _, eventType := range types {
+		if _, ok := eventTypes[eventType]; !ok {
+			return StatusFilter{}, errors.Wrapf(ErrInvalidStatusFilter, "unknown event type: %s", eventType)
+		}
+		filter.Types = append(filter.Types, eventType)
+	}
+	return filter, nil
+}
+
+// Empty returns true if filter matches events of all emails
+func (f StatusFilter) Empty() bool {
+	return uuid.Equal(f.EmailID, uuid.Nil) && f.From == "" && f.TenantID == ""
+}
+
+// Match check if event matches filter
+func (f StatusFilter) Match(event *StatusEvent) bool {
+	if !uuid.Equal(f.EmailID, uuid.Nil) && !uuid.Equal(f.EmailID, event.EmailID) {
+		return false
+	}
+	if f.From != "" && f.From != event.From {
+		return false
+	}
+	if f.TenantID != "" && f.TenantID != event.TenantID {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, eventType := range f.Types {
+		if eventType == event.Type {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook outbound webhook subscription, empty EventTypes subscribes to all event types,
+// secret is returned only when webhook is created
+type Webhook struct {
+	WebhookID           uuid.UUID  `json:"webhookID"`
+	URL                 string     `json:"url" validate:"required,url,max=2048"`
+	EventTypes          []string   `json:"eventTypes" validate:"omitempty,dive,oneof=created queued sent retrying failed dead_lettered"`
+	Secret              string     `json:"secret,omitempty" validate:"omitempty,min=16,max=250"`
+	Enabled             bool       `json:"enabled"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	DisabledAt          *time.Time `json:"disabledAt,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+// UpdateWebhookReq update webhook request, empty secret keeps current one,
+// enabling webhook resets its consecutive failures
+type UpdateWebhookReq struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	EventTypes []string `json:"eventTypes" validate:"omitempty,dive,oneof=created queued sent retrying failed dead_lettered"`
+	Secret     string   `json:"secret,omitempty" validate:"omitempty,min=16,max=250"`
+	Enabled    bool     `json:"enabled"`
+}
+
+// WebhooksList webhooks list response
+type WebhooksList struct {
+	Webhooks []*Webhook `json:"webhooks"`
+}
+
+// WebhookPayload body of webhook request
+type WebhookPayload struct {
+	Sequence   uint64       `json:"sequence"`
+	Type       string       `json:"type"`
+	OccurredAt time.Time    `json:"occurredAt"`
+	Data       *StatusEvent `json:"data"`
+}
+
+// NewWebhookPayload returns webhook payload of status event
+func NewWebhookPayload(event *StatusEvent) *WebhookPayload {
+	return &WebhookPayload{Sequence: event.Sequence, Type: event.Type, OccurredAt: event.OccurredAt, Data: event}
+}
+
+// WebhookDelivery delivery of event to webhook with its last attempt result
+type WebhookDelivery struct {
+	DeliveryID     uuid.UUID       `json:"deliveryID"`
+	WebhookID      uuid.UUID       `json:"webhookID"`
+	EventSequence  uint64          `json:"eventSequence"`
+	EventType      string          `json:"eventType"`
+	EmailID        uuid.UUID       `json:"emailID"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"`
+	LastStatusCode int             `json:"lastStatusCode,omitempty"`
+	LastError      string          `json:"lastError,omitempty"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+
+	// URL and Secret of webhook are set only for claimed deliveries
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+// WebhookDeliveriesList webhook delivery log response
+type WebhookDeliveriesList struct {
+	WebhookID  uuid.UUID          `json:"webhookID"`
+	Deliveries []*WebhookDelivery `json:"deliveries"`
+}
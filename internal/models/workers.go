@@ -0,0 +1,32 @@
+package models
+
+import "sort"
+
+// ScaleWorkersReq scale subscription workers request
+type ScaleWorkersReq struct {
+	Subject string `json:"subject" validate:"required"`
+	Workers int    `json:"workers" validate:"min=0,max=100"`
+}
+
+// SubscriptionWorkers running workers of subscription subject
+type SubscriptionWorkers struct {
+	Subject string `json:"subject"`
+	Workers int    `json:"workers"`
+}
+
+// WorkersList running workers per subject response
+type WorkersList struct {
+	Subscriptions []*SubscriptionWorkers `json:"subscriptions"`
+}
+
+// NewWorkersList create sorted by subject workers list
+func NewWorkersList(workers map[string]int) *WorkersList {
+	list := &WorkersList{Subscriptions: make([]*SubscriptionWorkers, 0, len(workers))}
+	for subject, num := range workers {
+		list.Subscriptions = append(list.Subscriptions, &SubscriptionWorkers{Subject: subject, Workers: num})
+	}
+	sort.Slice(list.Subscriptions, func(i, j int) bool {
+		return list.Subscriptions[i].Subject < list.Subscriptions[j].Subject
+	})
+	return list
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"strings"
+
+	httpErrors "github.com/AleksK1NG/nats-streaming/pkg/http_errors"
+	emailService "github.com/AleksK1NG/nats-streaming/proto/email"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	gatewayPrefix = "/api/v2"
+	// legacyPrefix v1 email routes having gRPC method are aliases of gateway routes
+	legacyPrefix = "/api/v1"
+)
+
+// mapGatewayRoutes serve REST gateway generated from email.proto, requests are proxied to gRPC server over loopback,
+// so REST API shares gRPC implementation, interceptors and server streaming
+func (s *server) mapGatewayRoutes(ctx context.Context, grpcAddr net.Addr, cert tls.Certificate, middlewares ...echo.MiddlewareFunc) error {
+	_, port, err := net.SplitHostPort(grpcAddr.String())
+	if err != nil {
+		return errors.Wrap(err, "net.SplitHostPort")
+	}
+
+	// loopback connection trusts exactly own server certificate, so host name is not verified
+	creds := credentials.NewTLS(&tls.Config{
+		InsecureSkipVerify:    true, // nolint: gosec
+		VerifyPeerCertificate: verifyPinnedCertificate(cert),
+	})
+
+	gwMux := runtime.NewServeMux(runtime.WithErrorHandler(s.gatewayErrorHandler))
+	if err := emailService.RegisterEmailServiceHandlerFromEndpoint(
+		ctx,
+		gwMux,
+		net.JoinHostPort("localhost", port),
+		[]grpc.DialOption{grpc.WithTransportCredentials(creds)},
+	); err != nil {
+		return errors.Wrap(err, "RegisterEmailServiceHandlerFromEndpoint")
+	}
+
+	v2 := s.echo.Group(gatewayPrefix, middlewares...)
+	v2.GET("/openapi.json", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, emailService.OpenAPISpec)
+	})
+	v2.Any("/*", echo.WrapHandler(gwMux))
+
+	legacy := s.echo.Group(legacyPrefix+"/email", middlewares...)
+	legacy.POST("", legacyAlias(gwMux))
+	legacy.POST("/batch", legacyAlias(gwMux))
+	legacy.GET("/search", legacyAlias(gwMux))
+	legacy.POST("/erasure", legacyAlias(gwMux))
+	legacy.GET("/:email_id", legacyAlias(gwMux))
+	legacy.PATCH("/:email_id", legacyAlias(gwMux))
+	legacy.GET("/:email_id/attempts", legacyAlias(gwMux))
+
+	return nil
+}
+
+// legacyAlias serve v1 route by gateway route of same path under /api/v2
+func legacyAlias(gwMux http.Handler) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		r := c.Request()
+		r.URL.Path = gatewayPrefix + strings.TrimPrefix(r.URL.Path, legacyPrefix)
+		if r.URL.RawPath != "" {
+			r.URL.RawPath = gatewayPrefix + strings.TrimPrefix(r.URL.RawPath, legacyPrefix)
+		}
+		gwMux.ServeHTTP(c.Response(), r)
+		return nil
+	}
+}
+
+// gatewayErrorHandler respond gRPC errors with the same rest error body as echo routes
+func (s *server) gatewayErrorHandler(
+	_ context.Context,
+	_ *runtime.ServeMux,
+	_ runtime.Marshaler,
+	w http.ResponseWriter,
+	_ *http.Request,
+	err error,
+) {
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	if err := httpErrors.ErrorResponse(w, httpErrors.NewStatusRestError(status.Convert(err))); err != nil {
+		s.log.Errorf("httpErrors.ErrorResponse: %v", err)
+	}
+}
+
+func verifyPinnedCertificate(cert tls.Certificate) func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 || len(cert.Certificate) == 0 || !bytes.Equal(rawCerts[0], cert.Certificate[0]) {
+			return errors.New("gateway: unexpected gRPC server certificate")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/pkg/health"
+	"github.com/AleksK1NG/nats-streaming/pkg/smtp"
+	"github.com/labstack/echo/v4"
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+	grpcHealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const emailServiceName = "emailService.EmailService"
+
+// newHealthChecker returns checker of postgresql primary, redis, nats streaming connection and smtp relay
+func (s *server) newHealthChecker(smtpClient smtp.SMTPClient) *health.Checker {
+	checker := health.NewChecker(s.cfg.Health.Timeout * time.Second)
+	checker.Register("postgresql", func(ctx context.Context) error {
+		_, err := s.pgCluster.Primary().Exec(ctx, "SELECT 1")
+		return err
+	})
+	checker.Register("redis", func(ctx context.Context) error {
+		return s.redis.Ping(ctx).Err()
+	})
+	checker.Register("nats", func(ctx context.Context) error {
+		nc := s.natsConn.NatsConn()
+		if nc == nil {
+			return errors.New("connection is closed")
+		}
+		if status := nc.Status(); status != nats.CONNECTED {
+			return errors.Errorf("connection status: %d", status)
+		}
+		return nil
+	})
+	checker.Register("smtp", smtpClient.Ping)
+	return checker
+}
+
+// runHealthChecks run dependency checks every interval and set grpc serving status of server and email service
+func (s *server) runHealthChecks(ctx context.Context, checker *health.Checker, healthServer *grpcHealth.Server) {
+	checker.RunEvery(ctx, s.cfg.Health.Interval*time.Second, func(report *health.Report) {
+		status := grpc_health_v1.HealthCheckResponse_SERVING
+		if !report.Up() {
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+			s.log.Warnf("health check failed: %+v", report.Checks)
+		}
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus(emailServiceName, status)
+	})
+}
+
+// mapHealthRoutes liveness reports only that process serves requests,
+// readiness reports last dependency checks and is unavailable until all of them passed
+func (s *server) mapHealthRoutes(checker *health.Checker) {
+	live := func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"status": health.StatusUp})
+	}
+	s.echo.GET("/live", live)
+	s.echo.GET("/health", live)
+	s.echo.GET("/ready", func(c echo.Context) error {
+		report := checker.Last()
+		if report == nil {
+			return c.JSON(http.StatusServiceUnavailable, &health.Report{Status: health.StatusDown, Checks: map[string]health.CheckResult{}})
+		}
+		if !report.Up() {
+			return c.JSON(http.StatusServiceUnavailable, report)
+		}
+		return c.JSON(http.StatusOK, report)
+	})
+}
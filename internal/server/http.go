@@ -1,35 +1,35 @@
 package server
 
 import (
-	"net/http"
 	"strings"
 	"time"
 
 	"github.com/AleksK1NG/nats-streaming/docs"
+	"github.com/AleksK1NG/nats-streaming/internal/middlewares"
+	"github.com/AleksK1NG/nats-streaming/pkg/health"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
-func (s *server) runHttpServer() {
-	s.echo.GET("/health", func(c echo.Context) error {
-		return c.String(http.StatusOK, "Ok")
-	})
+func (s *server) runHttpServer(checker *health.Checker, mw middlewares.MiddlewareManager) {
+	s.mapHealthRoutes(checker)
 	s.echo.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
-	s.mapRoutes()
+	s.mapRoutes(mw)
 
 	go func() {
-		s.echo.Server.ReadTimeout = time.Second * s.cfg.HTTP.ReadTimeout
-		s.echo.Server.WriteTimeout = time.Second * s.cfg.HTTP.WriteTimeout
-		s.echo.Server.MaxHeaderBytes = maxHeaderBytes
+		// StartTLS serves TLSServer, write timeout is set per route by middleware, so event streams and export are not bounded by it
+		s.echo.TLSServer.ReadTimeout = time.Second * s.cfg.HTTP.ReadTimeout
+		s.echo.TLSServer.MaxHeaderBytes = maxHeaderBytes
+		s.echo.TLSServer.ConnContext = middlewares.ConnContext
 		if err := s.echo.StartTLS(s.cfg.HTTP.Port, certFile, keyFile); err != nil {
 			s.log.Fatalf("Error starting TLS Server: ", err)
 		}
 	}()
 }
 
-func (s *server) mapRoutes() {
+func (s *server) mapRoutes(mw middlewares.MiddlewareManager) {
 	docs.SwaggerInfo.Version = "1.0"
 	docs.SwaggerInfo.Title = "Emails microservice"
 	docs.SwaggerInfo.Description = "Emails NATS gRPC microservice."
@@ -37,6 +37,7 @@ func (s *server) mapRoutes() {
 	docs.SwaggerInfo.BasePath = "/api/v1"
 
 	s.echo.GET("/swagger/*", echoSwagger.WrapHandler)
+	s.echo.Use(mw.WriteDeadline(isStreamingRoute))
 	s.echo.Use(middleware.Logger())
 	s.echo.Pre(middleware.HTTPSRedirect())
 	s.echo.Use(middleware.CORSWithConfig(middleware.CORSConfig{
@@ -44,6 +45,8 @@ func (s *server) mapRoutes() {
 		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, csrfTokenHeader},
 	}))
 	s.echo.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
+		// streams abort failed responses with http.ErrAbortHandler, so their panics are recovered by http server
+		Skipper:           isStreamingRoute,
 		StackSize:         stackSize,
 		DisablePrintStack: true,
 		DisableStackAll:   true,
@@ -52,9 +55,18 @@ func (s *server) mapRoutes() {
 	s.echo.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: gzipLevel,
 		Skipper: func(c echo.Context) bool {
-			return strings.Contains(c.Request().URL.Path, "swagger")
+			// event streams are flushed per event and export is compressed by its gzip param, so they are not compressed
+			return strings.Contains(c.Request().URL.Path, "swagger") || isStreamingRoute(c)
 		},
 	}))
 	s.echo.Use(middleware.Secure())
 	s.echo.Use(middleware.BodyLimit(bodyLimit))
 }
+
+// isStreamingRoute long lived event streams and export responses
+func isStreamingRoute(c echo.Context) bool {
+	path := c.Request().URL.Path
+	return strings.HasSuffix(path, "/email/events") ||
+		strings.HasSuffix(path, "/email/export") ||
+		strings.HasSuffix(path, "/status-events")
+}
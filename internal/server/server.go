@@ -11,25 +11,33 @@ import (
 
 	emailsV1 "github.com/AleksK1NG/nats-streaming/internal/email/delivery/http/v1"
 	"github.com/AleksK1NG/nats-streaming/internal/email/delivery/nats"
+	"github.com/AleksK1NG/nats-streaming/internal/email/events"
 	"github.com/AleksK1NG/nats-streaming/internal/interceptors"
 	"github.com/AleksK1NG/nats-streaming/internal/middlewares"
+	"github.com/AleksK1NG/nats-streaming/internal/models"
 	"github.com/AleksK1NG/nats-streaming/pkg/smtp"
+	webhookClient "github.com/AleksK1NG/nats-streaming/pkg/webhook"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/AleksK1NG/nats-streaming/config"
 	emailGrpc "github.com/AleksK1NG/nats-streaming/internal/email/delivery/grpc"
+	"github.com/AleksK1NG/nats-streaming/internal/email/partition"
 	"github.com/AleksK1NG/nats-streaming/internal/email/repository"
+	"github.com/AleksK1NG/nats-streaming/internal/email/retention"
 	"github.com/AleksK1NG/nats-streaming/internal/email/usecase"
+	"github.com/AleksK1NG/nats-streaming/internal/email/webhook"
 	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/AleksK1NG/nats-streaming/pkg/postgresql"
 	emailService "github.com/AleksK1NG/nats-streaming/proto/email"
 	"github.com/go-redis/redis/v8"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/nats-io/stan.go"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	grpcHealth "google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 
 	"github.com/go-playground/validator/v10"
@@ -54,13 +62,13 @@ const (
 )
 
 type server struct {
-	log      logger.Logger
-	cfg      *config.Config
-	natsConn stan.Conn
-	pgxPool  *pgxpool.Pool
-	tracer   opentracing.Tracer
-	echo     *echo.Echo
-	redis    *redis.Client
+	log       logger.Logger
+	cfg       *config.Config
+	natsConn  stan.Conn
+	pgCluster *postgresql.Cluster
+	tracer    opentracing.Tracer
+	echo      *echo.Echo
+	redis     *redis.Client
 }
 
 // NewServer constructor
@@ -68,11 +76,11 @@ func NewServer(
 	log logger.Logger,
 	cfg *config.Config,
 	natsConn stan.Conn,
-	pgxPool *pgxpool.Pool,
+	pgCluster *postgresql.Cluster,
 	tracer opentracing.Tracer,
 	redis *redis.Client,
 ) *server {
-	return &server{log: log, cfg: cfg, natsConn: natsConn, pgxPool: pgxPool, tracer: tracer, redis: redis, echo: echo.New()}
+	return &server{log: log, cfg: cfg, natsConn: natsConn, pgCluster: pgCluster, tracer: tracer, redis: redis, echo: echo.New()}
 }
 
 // Run start application
@@ -82,23 +90,53 @@ func (s *server) Run() error {
 
 	smtpClient := smtp.NewSmtpClient(s.cfg)
 	publisher := nats.NewPublisher(s.natsConn)
-	emailPgRepo := repository.NewEmailPGRepository(s.pgxPool)
+	go s.pgCluster.MonitorLag(ctx, s.cfg.PostgreSQL.ReplicaLagInterval*time.Second)
+
+	emailPgRepo := repository.NewEmailPGRepository(s.pgCluster)
 	emailRedisRepo := repository.NewEmailRedisRepository(s.redis)
-	emailUC := usecase.NewEmailUseCase(s.log, emailPgRepo, publisher, smtpClient, emailRedisRepo)
+	statusBus := events.NewBus(s.cfg.Nats.StatusEvents.BufferSize)
+	emailUC := usecase.NewEmailUseCase(s.log, s.cfg, emailPgRepo, publisher, smtpClient, emailRedisRepo, statusBus)
+	webhookRepo := repository.NewWebhookPGRepository(s.pgCluster.Primary())
+	webhookHTTPClient := webhookClient.NewHTTPClient(s.cfg.Webhooks.Timeout*time.Second, webhookClient.NewAddressPolicy(s.cfg.Webhooks))
+	webhookUC := usecase.NewWebhookUseCase(s.log, s.cfg, webhookRepo, webhookHTTPClient)
 
 	im := interceptors.NewInterceptorManager(s.log, s.cfg)
 	mw := middlewares.NewMiddlewareManager(s.log, s.cfg)
 
 	validate := validator.New()
+	if err := models.RegisterValidations(validate); err != nil {
+		return errors.Wrap(err, "models.RegisterValidations")
+	}
 
-	go func() {
-		emailSubscriber := nats.NewEmailSubscriber(s.natsConn, s.log, emailUC, validate)
-		emailSubscriber.Run(ctx)
-	}()
+	emailSubscriber := nats.NewEmailSubscriber(s.natsConn, s.log, s.cfg, emailUC, validate)
+	go emailSubscriber.Run(ctx)
+
+	if s.cfg.Partitions.Enabled {
+		partitionManager := partition.NewPartitionManager(s.log, s.cfg, repository.NewPartitionPGRepository(s.pgCluster.Primary()))
+		go partitionManager.Run(ctx)
+	}
+
+	if s.cfg.Webhooks.Enabled {
+		webhookDispatcher := webhook.NewDispatcher(s.log, s.cfg, s.natsConn, webhookUC)
+		go func() {
+			if err := webhookDispatcher.Run(ctx); err != nil {
+				s.log.Errorf("webhookDispatcher.Run: %v", err)
+			}
+		}()
+	}
+
+	if s.cfg.Retention.Enabled {
+		retentionJob := retention.NewRetentionJob(s.log, s.cfg, emailUC)
+		go retentionJob.Run(ctx)
+	}
+
+	checker := s.newHealthChecker(smtpClient)
+	healthServer := grpcHealth.NewServer()
+	go s.runHealthChecks(ctx, checker, healthServer)
 
 	go func() {
 		s.log.Infof("Server is listening on PORT: %s", s.cfg.HTTP.Port)
-		s.runHttpServer()
+		s.runHttpServer(checker, mw)
 	}()
 
 	metricsServer := echo.New()
@@ -113,9 +151,15 @@ func (s *server) Run() error {
 	v1 := s.echo.Group("/api/v1")
 	v1.Use(mw.Metrics)
 
-	emailHandlers := emailsV1.NewEmailHandlers(v1.Group("/email"), emailUC, s.log, validate)
+	emailHandlers := emailsV1.NewEmailHandlers(v1.Group("/email"), emailUC, s.log, s.cfg)
 	emailHandlers.MapRoutes()
 
+	adminHandlers := emailsV1.NewAdminHandlers(v1.Group("/admin"), emailSubscriber, s.log, validate)
+	adminHandlers.MapRoutes()
+
+	webhookHandlers := emailsV1.NewWebhookHandlers(v1.Group("/webhooks"), webhookUC, s.log, validate)
+	webhookHandlers.MapRoutes()
+
 	l, err := net.Listen("tcp", s.cfg.GRPC.Port)
 	if err != nil {
 		return errors.Wrap(err, "net.Listen")
@@ -143,12 +187,25 @@ func (s *server) Run() error {
 			im.Logger,
 		),
 		),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			grpc_ctxtags.StreamServerInterceptor(),
+			grpc_opentracing.StreamServerInterceptor(),
+			grpc_prometheus.StreamServerInterceptor,
+			grpcrecovery.StreamServerInterceptor(),
+			im.StreamLogger,
+		),
+		),
 	)
 
 	emailGRPCService := emailGrpc.NewEmailGRPCService(emailUC, s.log, validate)
 	emailService.RegisterEmailServiceServer(grpcServer, emailGRPCService)
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
 	grpc_prometheus.Register(grpcServer)
 
+	if err := s.mapGatewayRoutes(ctx, l.Addr(), cert, mw.Metrics); err != nil {
+		return errors.Wrap(err, "mapGatewayRoutes")
+	}
+
 	s.log.Infof("GRPC Server is listening on port: %s", s.cfg.GRPC.Port)
 	s.log.Fatal(grpcServer.Serve(l))
 
@@ -166,14 +223,15 @@ func (s *server) Run() error {
 		s.log.Errorf("ctx.Done: %v", done)
 	}
 
-	if err := s.echo.Server.Shutdown(ctx); err != nil {
-		return errors.Wrap(err, "echo.Server.Shutdown")
+	if err := s.echo.TLSServer.Shutdown(ctx); err != nil {
+		return errors.Wrap(err, "echo.TLSServer.Shutdown")
 	}
 
 	if err := metricsServer.Shutdown(ctx); err != nil {
 		s.log.Errorf("metricsServer.Shutdown: %v", err)
 	}
 
+	healthServer.Shutdown()
 	grpcServer.GracefulStop()
 	s.log.Info("Server Exited Properly")
 
@@ -2,9 +2,8 @@ CREATE EXTENSION IF NOT EXISTS citext;
 CREATE EXTENSION IF NOT EXISTS pg_trgm;
 CREATE EXTENSION IF NOT EXISTS btree_gist;
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
-DROP TABLE IF EXISTS emails CASCADE;
 
-CREATE TABLE emails
+CREATE TABLE IF NOT EXISTS emails
 (
     email_id     UUID PRIMARY KEY         DEFAULT uuid_generate_v4(),
     address_from VARCHAR(250) NOT NULL CHECK ( address_from <> '' ),
@@ -16,12 +15,13 @@ CREATE TABLE emails
 
 
 ALTER TABLE emails
-    ADD COLUMN document_with_idx tsvector;
+    ADD COLUMN IF NOT EXISTS document_with_idx tsvector;
 UPDATE emails
-set document_with_idx = to_tsvector(emails.address_to || ' ' || emails.subject || ' ' || emails.message);
-CREATE INDEX document_idx ON emails USING gin (document_with_idx);
+set document_with_idx = to_tsvector(emails.address_to || ' ' || emails.subject || ' ' || emails.message)
+WHERE document_with_idx IS NULL;
+CREATE INDEX IF NOT EXISTS document_idx ON emails USING gin (document_with_idx);
 
-CREATE FUNCTION emails_tsvector_trigger() RETURNS trigger AS
+CREATE OR REPLACE FUNCTION emails_tsvector_trigger() RETURNS trigger AS
 $$
 begin
     new.document_with_idx := to_tsvector(new.address_to || ' ' || new.subject || ' ' || new.message);
@@ -29,6 +29,7 @@ begin
 end
 $$ LANGUAGE plpgsql;
 
+DROP TRIGGER IF EXISTS tsvectorupdate ON emails;
 CREATE TRIGGER tsvectorupdate
     BEFORE INSERT OR UPDATE
     ON emails
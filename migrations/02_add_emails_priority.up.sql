@@ -0,0 +1,2 @@
+ALTER TABLE emails
+    ADD COLUMN IF NOT EXISTS priority VARCHAR(10) NOT NULL DEFAULT 'normal' CHECK ( priority IN ('high', 'normal', 'low') );
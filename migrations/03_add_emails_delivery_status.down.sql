@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS emails_retry_idx;
+
+ALTER TABLE emails
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS attempts,
+    DROP COLUMN IF EXISTS next_attempt_at,
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,8 @@
+ALTER TABLE emails
+    ADD COLUMN IF NOT EXISTS status          VARCHAR(20) NOT NULL DEFAULT 'queued' CHECK ( status IN ('queued', 'retrying', 'sent', 'failed') ),
+    ADD COLUMN IF NOT EXISTS attempts        INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS last_error      TEXT,
+    ADD COLUMN IF NOT EXISTS updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS emails_retry_idx ON emails (next_attempt_at) WHERE status = 'retrying';
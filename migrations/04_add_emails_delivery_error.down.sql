@@ -0,0 +1,4 @@
+ALTER TABLE emails
+    DROP COLUMN IF EXISTS last_error_class,
+    DROP COLUMN IF EXISTS last_error_code,
+    DROP COLUMN IF EXISTS last_error_enhanced_code;
@@ -0,0 +1,4 @@
+ALTER TABLE emails
+    ADD COLUMN IF NOT EXISTS last_error_class         VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS last_error_code          INTEGER,
+    ADD COLUMN IF NOT EXISTS last_error_enhanced_code VARCHAR(20);
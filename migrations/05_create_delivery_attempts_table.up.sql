@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS delivery_attempts
+(
+    attempt_id          UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    email_id            UUID                     NOT NULL REFERENCES emails (email_id) ON DELETE CASCADE,
+    attempt             INTEGER                  NOT NULL,
+    worker_id           VARCHAR(250)             NOT NULL,
+    relay               VARCHAR(250)             NOT NULL,
+    status              VARCHAR(20)              NOT NULL CHECK ( status IN ('sent', 'failed') ),
+    started_at          TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at         TIMESTAMP WITH TIME ZONE NOT NULL,
+    error_class         VARCHAR(20),
+    error_code          INTEGER,
+    error_enhanced_code VARCHAR(20),
+    error               TEXT
+);
+
+CREATE INDEX IF NOT EXISTS delivery_attempts_email_id_idx ON delivery_attempts (email_id, attempt);
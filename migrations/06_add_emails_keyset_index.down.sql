@@ -0,0 +1 @@
+DROP INDEX IF EXISTS emails_created_at_email_id_idx;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS emails_created_at_email_id_idx ON emails (created_at, email_id);
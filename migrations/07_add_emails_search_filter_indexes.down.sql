@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS emails_subject_prefix_idx;
+DROP INDEX IF EXISTS emails_status_created_at_idx;
+DROP INDEX IF EXISTS emails_address_to_idx;
+DROP INDEX IF EXISTS emails_address_from_idx;
@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS emails_address_from_idx ON emails (address_from, created_at);
+CREATE INDEX IF NOT EXISTS emails_address_to_idx ON emails (address_to, created_at);
+CREATE INDEX IF NOT EXISTS emails_status_created_at_idx ON emails (status, created_at);
+CREATE INDEX IF NOT EXISTS emails_subject_prefix_idx ON emails (subject text_pattern_ops);
@@ -0,0 +1,13 @@
+CREATE OR REPLACE FUNCTION emails_tsvector_trigger() RETURNS trigger AS
+$$
+begin
+    new.document_with_idx := to_tsvector(new.address_to || ' ' || new.subject || ' ' || new.message);
+    return new;
+end
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE emails
+    DROP COLUMN IF EXISTS language;
+
+UPDATE emails
+SET document_with_idx = to_tsvector(address_to || ' ' || subject || ' ' || message);
@@ -0,0 +1,18 @@
+ALTER TABLE emails
+    ADD COLUMN IF NOT EXISTS language REGCONFIG NOT NULL DEFAULT 'english';
+
+CREATE OR REPLACE FUNCTION emails_tsvector_trigger() RETURNS trigger AS
+$$
+begin
+    new.document_with_idx :=
+                setweight(to_tsvector(new.language, new.subject), 'A') ||
+                setweight(to_tsvector('simple', new.address_from || ' ' || new.address_to), 'B') ||
+                setweight(to_tsvector(new.language, new.message), 'D');
+    return new;
+end
+$$ LANGUAGE plpgsql;
+
+UPDATE emails
+SET document_with_idx = setweight(to_tsvector(language, subject), 'A') ||
+                        setweight(to_tsvector('simple', address_from || ' ' || address_to), 'B') ||
+                        setweight(to_tsvector(language, message), 'D');
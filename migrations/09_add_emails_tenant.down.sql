@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS emails_tenant_created_at_idx;
+
+ALTER TABLE emails
+    DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,4 @@
+ALTER TABLE emails
+    ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS emails_tenant_created_at_idx ON emails (tenant_id, created_at);
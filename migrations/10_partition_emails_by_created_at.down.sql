@@ -0,0 +1,46 @@
+-- rows of detached partitions are not restored
+ALTER TABLE delivery_attempts
+    DROP CONSTRAINT IF EXISTS delivery_attempts_email_id_fkey;
+
+DROP TABLE IF EXISTS email_ids;
+
+CREATE TABLE emails_plain
+(
+    LIKE emails INCLUDING DEFAULTS INCLUDING CONSTRAINTS
+);
+
+INSERT INTO emails_plain
+SELECT *
+FROM emails;
+
+DROP TABLE emails;
+ALTER TABLE emails_plain
+    RENAME TO emails;
+
+ALTER TABLE emails
+    ADD PRIMARY KEY (email_id),
+    ALTER COLUMN created_at DROP NOT NULL;
+
+CREATE INDEX IF NOT EXISTS document_idx ON emails USING gin (document_with_idx);
+CREATE INDEX IF NOT EXISTS emails_retry_idx ON emails (next_attempt_at) WHERE status = 'retrying';
+CREATE INDEX IF NOT EXISTS emails_created_at_email_id_idx ON emails (created_at, email_id);
+CREATE INDEX IF NOT EXISTS emails_address_from_idx ON emails (address_from, created_at);
+CREATE INDEX IF NOT EXISTS emails_address_to_idx ON emails (address_to, created_at);
+CREATE INDEX IF NOT EXISTS emails_status_created_at_idx ON emails (status, created_at);
+CREATE INDEX IF NOT EXISTS emails_subject_prefix_idx ON emails (subject text_pattern_ops);
+CREATE INDEX IF NOT EXISTS emails_tenant_created_at_idx ON emails (tenant_id, created_at);
+
+CREATE TRIGGER tsvectorupdate
+    BEFORE INSERT OR UPDATE
+    ON emails
+    FOR EACH ROW
+EXECUTE PROCEDURE emails_tsvector_trigger();
+
+DROP FUNCTION IF EXISTS emails_email_ids_trigger();
+
+DELETE
+FROM delivery_attempts
+WHERE email_id NOT IN (SELECT email_id FROM emails);
+
+ALTER TABLE delivery_attempts
+    ADD CONSTRAINT delivery_attempts_email_id_fkey FOREIGN KEY (email_id) REFERENCES emails (email_id) ON DELETE CASCADE;
@@ -0,0 +1,96 @@
+-- partition bounds are calendar months in UTC
+SET LOCAL TIME ZONE 'UTC';
+
+ALTER TABLE delivery_attempts
+    DROP CONSTRAINT IF EXISTS delivery_attempts_email_id_fkey;
+
+-- created_at is partition key, emails without it are kept with best known time
+UPDATE emails
+SET created_at = COALESCE(updated_at, now())
+WHERE created_at IS NULL;
+
+CREATE TABLE emails_partitioned
+(
+    LIKE emails INCLUDING DEFAULTS INCLUDING CONSTRAINTS
+) PARTITION BY RANGE (created_at);
+
+ALTER TABLE emails_partitioned
+    ALTER COLUMN created_at SET NOT NULL;
+
+CREATE TABLE emails_default PARTITION OF emails_partitioned DEFAULT;
+
+-- monthly partitions from the oldest email up to three months ahead
+DO
+$$
+    DECLARE
+        month_start TIMESTAMP WITH TIME ZONE;
+    BEGIN
+        month_start := date_trunc('month', COALESCE((SELECT min(created_at) FROM emails), now()));
+        WHILE month_start < date_trunc('month', now()) + INTERVAL '4 months'
+            LOOP
+                EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF emails_partitioned FOR VALUES FROM (%L) TO (%L)',
+                               'emails_p' || to_char(month_start, 'YYYYMM'),
+                               month_start, month_start + INTERVAL '1 month');
+                month_start := month_start + INTERVAL '1 month';
+            END LOOP;
+    END
+$$;
+
+INSERT INTO emails_partitioned
+SELECT *
+FROM emails;
+
+-- partitioned primary key must include created_at, so email id uniqueness across partitions
+-- and created_at lookup by email id are kept in separate table
+CREATE TABLE IF NOT EXISTS email_ids
+(
+    email_id   UUID PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+INSERT INTO email_ids (email_id, created_at)
+SELECT email_id, created_at
+FROM emails;
+
+DROP TABLE emails;
+ALTER TABLE emails_partitioned
+    RENAME TO emails;
+
+ALTER TABLE emails
+    ADD PRIMARY KEY (email_id, created_at);
+
+CREATE INDEX IF NOT EXISTS document_idx ON emails USING gin (document_with_idx);
+CREATE INDEX IF NOT EXISTS emails_retry_idx ON emails (next_attempt_at) WHERE status = 'retrying';
+CREATE INDEX IF NOT EXISTS emails_created_at_email_id_idx ON emails (created_at, email_id);
+CREATE INDEX IF NOT EXISTS emails_address_from_idx ON emails (address_from, created_at);
+CREATE INDEX IF NOT EXISTS emails_address_to_idx ON emails (address_to, created_at);
+CREATE INDEX IF NOT EXISTS emails_status_created_at_idx ON emails (status, created_at);
+CREATE INDEX IF NOT EXISTS emails_subject_prefix_idx ON emails (subject text_pattern_ops);
+CREATE INDEX IF NOT EXISTS emails_tenant_created_at_idx ON emails (tenant_id, created_at);
+
+CREATE TRIGGER tsvectorupdate
+    BEFORE INSERT OR UPDATE
+    ON emails
+    FOR EACH ROW
+EXECUTE PROCEDURE emails_tsvector_trigger();
+
+CREATE OR REPLACE FUNCTION emails_email_ids_trigger() RETURNS trigger AS
+$$
+begin
+    insert into email_ids (email_id, created_at) values (new.email_id, new.created_at);
+    return null;
+end
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER emailidsinsert
+    AFTER INSERT
+    ON emails
+    FOR EACH ROW
+EXECUTE PROCEDURE emails_email_ids_trigger();
+
+DELETE
+FROM delivery_attempts
+WHERE email_id NOT IN (SELECT email_id FROM email_ids);
+
+ALTER TABLE delivery_attempts
+    ADD CONSTRAINT delivery_attempts_email_id_fkey FOREIGN KEY (email_id) REFERENCES email_ids (email_id) ON DELETE CASCADE;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS erasures;
+DROP TABLE IF EXISTS dead_letters;
+
+DROP INDEX IF EXISTS emails_lower_address_to_idx;
+DROP INDEX IF EXISTS emails_lower_address_from_idx;
+
+ALTER TABLE emails
+    DROP COLUMN IF EXISTS deleted_at;
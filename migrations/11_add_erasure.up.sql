@@ -0,0 +1,33 @@
+ALTER TABLE emails
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS emails_lower_address_from_idx ON emails (lower(address_from));
+CREATE INDEX IF NOT EXISTS emails_lower_address_to_idx ON emails (lower(address_to));
+
+CREATE TABLE IF NOT EXISTS dead_letters
+(
+    dead_letter_id UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    email_id       UUID,
+    address_from   VARCHAR(250)             NOT NULL DEFAULT '',
+    address_to     VARCHAR(250)             NOT NULL DEFAULT '',
+    subject        VARCHAR(250)             NOT NULL,
+    sequence       BIGINT                   NOT NULL,
+    data           BYTEA                    NOT NULL,
+    error          TEXT                     NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS dead_letters_email_id_idx ON dead_letters (email_id);
+CREATE INDEX IF NOT EXISTS dead_letters_lower_address_from_idx ON dead_letters (lower(address_from));
+CREATE INDEX IF NOT EXISTS dead_letters_lower_address_to_idx ON dead_letters (lower(address_to));
+
+CREATE TABLE IF NOT EXISTS erasures
+(
+    erasure_id   UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    address_hash VARCHAR(64)              NOT NULL,
+    mode         VARCHAR(10)              NOT NULL CHECK ( mode IN ('delete', 'redact') ),
+    emails       BIGINT                   NOT NULL,
+    attempts     BIGINT                   NOT NULL,
+    dead_letters BIGINT                   NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
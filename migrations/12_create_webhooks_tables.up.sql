@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS webhooks
+(
+    webhook_id           UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    url                  VARCHAR(2048)            NOT NULL,
+    event_types          TEXT[]                   NOT NULL DEFAULT '{}',
+    secret               VARCHAR(250)             NOT NULL,
+    enabled              BOOLEAN                  NOT NULL DEFAULT TRUE,
+    consecutive_failures INTEGER                  NOT NULL DEFAULT 0,
+    disabled_at          TIMESTAMP WITH TIME ZONE,
+    created_at           TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at           TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    delivery_id      UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    webhook_id       UUID                     NOT NULL REFERENCES webhooks (webhook_id) ON DELETE CASCADE,
+    event_sequence   BIGINT                   NOT NULL,
+    event_type       VARCHAR(20)              NOT NULL,
+    email_id         UUID                     NOT NULL,
+    payload          JSONB                    NOT NULL,
+    status           VARCHAR(20)              NOT NULL DEFAULT 'pending' CHECK ( status IN ('pending', 'delivered', 'failed') ),
+    attempts         INTEGER                  NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMP WITH TIME ZONE,
+    last_status_code INTEGER,
+    last_error       TEXT,
+    delivered_at     TIMESTAMP WITH TIME ZONE,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (webhook_id, event_sequence)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, created_at DESC);
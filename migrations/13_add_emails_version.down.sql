@@ -0,0 +1,3 @@
+ALTER TABLE emails
+    DROP COLUMN IF EXISTS sending_at,
+    DROP COLUMN IF EXISTS version;
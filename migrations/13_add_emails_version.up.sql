@@ -0,0 +1,5 @@
+-- version is incremented by every edit for optimistic concurrency,
+-- sending_at is set while email is claimed by send worker, so it can't be edited
+ALTER TABLE emails
+    ADD COLUMN IF NOT EXISTS version    INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS sending_at TIMESTAMP WITH TIME ZONE;
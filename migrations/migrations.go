@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS embedded sql migrations, files are named <version>_<name>.<up|down>.sql
+//
+//go:embed *.sql
+var FS embed.FS
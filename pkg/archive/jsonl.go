@@ -0,0 +1,94 @@
+package archive
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	jsonlGzExt = ".jsonl.gz"
+	tmpExt     = ".tmp"
+)
+
+// JSONLWriter gzip compressed JSON lines archive file writer,
+// records are written to temporary file which is renamed to final name on Close
+type JSONLWriter struct {
+	path    string
+	tmpPath string
+	file    *os.File
+	gz      *gzip.Writer
+	enc     *json.Encoder
+	count   int
+}
+
+// NewJSONLWriter create archive file dir/name.jsonl.gz
+func NewJSONLWriter(dir string, name string) (*JSONLWriter, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, errors.Wrap(err, "os.MkdirAll")
+	}
+
+	path := filepath.Join(dir, name+jsonlGzExt)
+	tmpPath := path + tmpExt
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return nil, errors.Wrap(err, "os.OpenFile")
+	}
+
+	gz := gzip.NewWriter(file)
+	return &JSONLWriter{path: path, tmpPath: tmpPath, file: file, gz: gz, enc: json.NewEncoder(gz)}, nil
+}
+
+// Write write record as single JSON line
+func (w *JSONLWriter) Write(record interface{}) error {
+	if err := w.enc.Encode(record); err != nil {
+		return errors.Wrap(err, "enc.Encode")
+	}
+	w.count++
+	return nil
+}
+
+// Flush flush written records to disk, records are durable after Flush returns
+func (w *JSONLWriter) Flush() error {
+	if err := w.gz.Flush(); err != nil {
+		return errors.Wrap(err, "gz.Flush")
+	}
+	if err := w.file.Sync(); err != nil {
+		return errors.Wrap(err, "file.Sync")
+	}
+	return nil
+}
+
+// Count returns number of written records
+func (w *JSONLWriter) Count() int {
+	return w.count
+}
+
+// Path returns final archive file path
+func (w *JSONLWriter) Path() string {
+	return w.path
+}
+
+// Close finish archive file, empty archive is removed
+func (w *JSONLWriter) Close() error {
+	if err := w.gz.Close(); err != nil {
+		return errors.Wrap(err, "gz.Close")
+	}
+	if err := w.file.Sync(); err != nil {
+		return errors.Wrap(err, "file.Sync")
+	}
+	if err := w.file.Close(); err != nil {
+		return errors.Wrap(err, "file.Close")
+	}
+
+	if w.count == 0 {
+		return os.Remove(w.tmpPath)
+	}
+	if err := os.Rename(w.tmpPath, w.path); err != nil {
+		return errors.Wrap(err, "os.Rename")
+	}
+	return nil
+}
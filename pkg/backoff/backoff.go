@@ -0,0 +1,58 @@
+package backoff
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/config"
+)
+
+// Policy exponential backoff with jitter retry policy
+type Policy struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	Jitter          float64
+	MaxAttempts     int
+	MaxAge          time.Duration
+}
+
+// NewPolicy retry policy constructor
+func NewPolicy(cfg config.RetryPolicy) *Policy {
+	return &Policy{
+		InitialInterval: cfg.InitialInterval * time.Second,
+		MaxInterval:     cfg.MaxInterval * time.Second,
+		Multiplier:      cfg.Multiplier,
+		Jitter:          cfg.Jitter,
+		MaxAttempts:     cfg.MaxAttempts,
+		MaxAge:          cfg.MaxAge * time.Second,
+	}
+}
+
+// Delay returns randomized delay before next attempt after given number of failed attempts
+func (p *Policy) Delay(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	delay := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(attempts-1))
+	if maxInterval := float64(p.MaxInterval); maxInterval > 0 && delay > maxInterval {
+		delay = maxInterval
+	}
+
+	if p.Jitter > 0 {
+		delta := p.Jitter * delay
+		delay = delay - delta + rand.Float64()*(2*delta)
+	}
+
+	return time.Duration(delay)
+}
+
+// Exhausted returns true if no more attempts are allowed for given number of attempts and first attempt time
+func (p *Policy) Exhausted(attempts int, since time.Time) bool {
+	if p.MaxAttempts > 0 && attempts >= p.MaxAttempts {
+		return true
+	}
+	return p.MaxAge > 0 && time.Since(since) >= p.MaxAge
+}
@@ -5,9 +5,10 @@ const (
 	GRPC_PORT    = "GRPC_PORT"
 	METRICS_PORT = "METRICS_PORT"
 
-	NATS_URL       = "NATS_URL"
-	CLUSTER_ID     = "CLUSTER_ID"
-	NATS_CLIENT_ID = "NATS_CLIENT_ID"
+	NATS_URL            = "NATS_URL"
+	NATS_MONITORING_URL = "NATS_MONITORING_URL"
+	CLUSTER_ID          = "CLUSTER_ID"
+	NATS_CLIENT_ID      = "NATS_CLIENT_ID"
 
 	MAIL_SERVICE   = "MAIL_SERVICE"
 	REDIS_URL      = "REDIS_URL"
@@ -0,0 +1,153 @@
+package domainErrors
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+// Kind kind of domain error, transports map it to gRPC code and http status
+type Kind int
+
+const (
+	Internal Kind = iota
+	InvalidArgument
+	NotFound
+	AlreadyExists
+	FailedPrecondition
+	Aborted
+	OutOfRange
+	ResourceExhausted
+	Unavailable
+)
+
+// FieldViolation invalid field of request
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// Resource resource error refers to
+type Resource struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// Error typed domain error, Message is safe to return to clients
+type Error struct {
+	Kind       Kind
+	Message    string
+	Resource   *Resource
+	Violations []FieldViolation
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+// Unwrap returns cause of error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns domain error of kind, it is used for sentinel errors
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// NewNotFound returns not found error of resource
+func NewNotFound(resourceType, name string, err error) *Error {
+	return &Error{
+		Kind:     NotFound,
+		Message:  fmt.Sprintf("%s not found", resourceType),
+		Resource: &Resource{Type: resourceType, Name: name},
+		Err:      err,
+	}
+}
+
+// NewAlreadyExists returns already exists error of resource
+func NewAlreadyExists(resourceType, name string, err error) *Error {
+	return &Error{
+		Kind:     AlreadyExists,
+		Message:  fmt.Sprintf("%s already exists", resourceType),
+		Resource: &Resource{Type: resourceType, Name: name},
+		Err:      err,
+	}
+}
+
+// NewConflict returns error of concurrent modification of resource, client should read resource again and retry
+func NewConflict(resourceType, name string, err error) *Error {
+	return &Error{
+		Kind:     Aborted,
+		Message:  fmt.Sprintf("%s was modified concurrently", resourceType),
+		Resource: &Resource{Type: resourceType, Name: name},
+		Err:      err,
+	}
+}
+
+// NewFieldError returns invalid argument error of single request field
+func NewFieldError(field string, err error) *Error {
+	return &Error{
+		Kind:       InvalidArgument,
+		Message:    "Invalid field",
+		Violations: []FieldViolation{{Field: field, Description: err.Error()}},
+		Err:        err,
+	}
+}
+
+// NewValidationError returns invalid argument error with violation of each failed validator field
+func NewValidationError(err error) *Error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return &Error{Kind: InvalidArgument, Message: "Validation failed", Err: err}
+	}
+
+	violations := make([]FieldViolation, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		violations = append(violations, FieldViolation{Field: fieldName(fieldErr), Description: fieldDescription(fieldErr)})
+	}
+	return &Error{Kind: InvalidArgument, Message: "Validation failed", Violations: violations, Err: err}
+}
+
+// NewUnavailable returns error of unavailable dependency, clients may retry after retryAfter
+func NewUnavailable(message string, retryAfter time.Duration, err error) *Error {
+	return &Error{Kind: Unavailable, Message: message, RetryAfter: retryAfter, Err: err}
+}
+
+// From returns domain error of err chain, validator errors are converted to invalid argument error,
+// returns nil if err chain has no domain error
+func From(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return NewValidationError(validationErrs)
+	}
+	return nil
+}
+
+// fieldName returns field path without name of validated struct, e.g. Email.To[0] is To[0]
+func fieldName(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func fieldDescription(fieldErr validator.FieldError) string {
+	if fieldErr.Param() != "" {
+		return fmt.Sprintf("failed on '%s=%s' rule", fieldErr.Tag(), fieldErr.Param())
+	}
+	return fmt.Sprintf("failed on '%s' rule", fieldErr.Tag())
+}
@@ -2,14 +2,25 @@ package grpcErrors
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"net/http"
 	"strings"
 
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	"github.com/AleksK1NG/nats-streaming/pkg/smtp"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// SMTPErrorDomain domain of ErrorInfo status details of SMTP send errors
+	SMTPErrorDomain = "smtp"
+	// messageLocale locale of domain error messages
+	messageLocale = "en-US"
 )
 
 var (
@@ -21,9 +32,19 @@ var (
 
 // ParseGRPCErrStatusCode Parse error and get code
 func ParseGRPCErrStatusCode(err error) codes.Code {
+	var sendErr *smtp.SendError
+	if errors.As(err, &sendErr) {
+		if sendErr.Permanent() {
+			return codes.FailedPrecondition
+		}
+		return codes.Unavailable
+	}
+
+	if domainErr := domainErrors.From(err); domainErr != nil {
+		return MapDomainKindToCode(domainErr.Kind)
+	}
+
 	switch {
-	case errors.Is(err, sql.ErrNoRows):
-		return codes.NotFound
 	case errors.Is(err, context.Canceled):
 		return codes.Canceled
 	case errors.Is(err, context.DeadlineExceeded):
@@ -34,14 +55,29 @@ func ParseGRPCErrStatusCode(err error) codes.Code {
 		return codes.Unauthenticated
 	case errors.Is(err, ErrInvalidSessionId):
 		return codes.PermissionDenied
-	case strings.Contains(err.Error(), "Validate"):
+	}
+	return codes.Internal
+}
+
+// MapDomainKindToCode Map domain error kind to GRPC code
+func MapDomainKindToCode(kind domainErrors.Kind) codes.Code {
+	switch kind {
+	case domainErrors.InvalidArgument:
 		return codes.InvalidArgument
-	case strings.Contains(err.Error(), "redis"):
+	case domainErrors.NotFound:
 		return codes.NotFound
-	case errors.Is(err, context.Canceled):
-		return codes.Canceled
-	case errors.Is(err, context.DeadlineExceeded):
-		return codes.DeadlineExceeded
+	case domainErrors.AlreadyExists:
+		return codes.AlreadyExists
+	case domainErrors.FailedPrecondition:
+		return codes.FailedPrecondition
+	case domainErrors.Aborted:
+		return codes.Aborted
+	case domainErrors.OutOfRange:
+		return codes.OutOfRange
+	case domainErrors.ResourceExhausted:
+		return codes.ResourceExhausted
+	case domainErrors.Unavailable:
+		return codes.Unavailable
 	}
 	return codes.Internal
 }
@@ -65,11 +101,78 @@ func MapGRPCErrCodeToHttpStatus(code codes.Code) int {
 		return http.StatusGatewayTimeout
 	case codes.InvalidArgument:
 		return http.StatusBadRequest
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.OutOfRange:
+		return http.StatusGone
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Aborted:
+		return http.StatusConflict
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
 	}
 	return http.StatusInternalServerError
 }
 
-// ErrorResponse GRPC Error response
+// ErrorResponse GRPC Error response, SMTP send errors are attached as ErrorInfo status details,
+// domain errors as LocalizedMessage, BadRequest field violations, ResourceInfo and RetryInfo status details
 func ErrorResponse(err error, msg string) error {
-	return status.Errorf(ParseGRPCErrStatusCode(err), fmt.Sprintf("%s: %v", msg, err))
+	st := status.New(ParseGRPCErrStatusCode(err), fmt.Sprintf("%s: %v", msg, err))
+
+	details := make([]proto.Message, 0, 4)
+	var sendErr *smtp.SendError
+	if errors.As(err, &sendErr) {
+		details = append(details, &errdetails.ErrorInfo{
+			Reason: smtpErrorReason(sendErr),
+			Domain: SMTPErrorDomain,
+			Metadata: map[string]string{
+				"class":        sendErr.Class,
+				"code":         sendErr.CodeLabel(),
+				"enhancedCode": sendErr.EnhancedCode,
+				"message":      sendErr.Message,
+			},
+		})
+	}
+	if domainErr := domainErrors.From(err); domainErr != nil {
+		details = append(details, domainErrorDetails(domainErr)...)
+	}
+
+	if len(details) > 0 {
+		withDetails, detailsErr := st.WithDetails(details...)
+		if detailsErr == nil {
+			st = withDetails
+		}
+	}
+
+	return st.Err()
+}
+
+func domainErrorDetails(domainErr *domainErrors.Error) []proto.Message {
+	details := make([]proto.Message, 0, 4)
+	details = append(details, &errdetails.LocalizedMessage{Locale: messageLocale, Message: domainErr.Message})
+	if len(domainErr.Violations) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(domainErr.Violations))
+		for _, v := range domainErr.Violations {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Description})
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if domainErr.Resource != nil {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: domainErr.Resource.Type,
+			ResourceName: domainErr.Resource.Name,
+			Description:  domainErr.Message,
+		})
+	}
+	if domainErr.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(domainErr.RetryAfter)})
+	}
+	return details
+}
+
+func smtpErrorReason(sendErr *smtp.SendError) string {
+	return "SMTP_" + strings.ToUpper(sendErr.Class)
 }
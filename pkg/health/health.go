@@ -0,0 +1,134 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp   = "UP"
+	StatusDown = "DOWN"
+)
+
+// CheckFunc dependency check, nil error means dependency is available
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult result of single dependency check
+type CheckResult struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Report result of all dependency checks, status is up only if all checks passed
+type Report struct {
+	Status    string                 `json:"status"`
+	Checks    map[string]CheckResult `json:"checks"`
+	CheckedAt time.Time              `json:"checkedAt"`
+}
+
+// Up returns true if all checks passed
+func (r *Report) Up() bool {
+	return r != nil && r.Status == StatusUp
+}
+
+type namedCheck struct {
+	name  string
+	check CheckFunc
+}
+
+// Checker runs registered dependency checks concurrently and keeps last report
+type Checker struct {
+	timeout time.Duration
+	mu      sync.RWMutex
+	checks  []namedCheck
+	last    *Report
+}
+
+// NewChecker dependency checker constructor, each check is canceled after timeout
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Register add named dependency check
+func (c *Checker) Register(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Run run all checks and store report as last one
+func (c *Checker) Run(ctx context.Context) *Report {
+	c.mu.RLock()
+	checks := c.checks
+	c.mu.RUnlock()
+
+	report := &Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(checks))}
+
+	var wg sync.WaitGroup
+	var resMu sync.Mutex
+	for _, nc := range checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := nc.check(checkCtx)
+			result := CheckResult{Status: StatusUp, Duration: time.Since(start).String()}
+			if err != nil {
+				result.Status = StatusDown
+				result.Error = err.Error()
+			}
+			checkStatus.WithLabelValues(nc.name).Set(statusValue(err))
+
+			resMu.Lock()
+			defer resMu.Unlock()
+			report.Checks[nc.name] = result
+			if err != nil {
+				report.Status = StatusDown
+			}
+		}(nc)
+	}
+	wg.Wait()
+	report.CheckedAt = time.Now().UTC()
+
+	c.mu.Lock()
+	c.last = report
+	c.mu.Unlock()
+
+	return report
+}
+
+// Last returns last report, nil if checks were not run yet
+func (c *Checker) Last() *Report {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.last
+}
+
+// RunEvery run checks every interval until context is done, onReport is called with each report
+func (c *Checker) RunEvery(ctx context.Context, interval time.Duration, onReport func(report *Report)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		onReport(c.Run(ctx))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func statusValue(err error) float64 {
+	if err != nil {
+		return 0
+	}
+	return 1
+}
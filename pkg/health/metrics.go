@@ -0,0 +1,11 @@
+package health
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var checkStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "health_check_status",
+	Help: "The last result of dependency health check, 1 if dependency is available",
+}, []string{"check"})
@@ -2,14 +2,22 @@ package httpErrors
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	grpcErrors "github.com/AleksK1NG/nats-streaming/pkg/grpc_errors"
+	"github.com/AleksK1NG/nats-streaming/pkg/smtp"
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -25,6 +33,7 @@ const (
 	ErrInvalidEmail     = "Invalid email"
 	ErrInvalidPassword  = "Invalid password"
 	ErrInvalidField     = "Invalid field"
+	ErrMailDelivery     = "Mail delivery error"
 )
 
 var (
@@ -59,9 +68,12 @@ type RestErr interface {
 
 // RestError response struct
 type RestError struct {
-	ErrStatus int         `json:"status,omitempty"`
-	ErrError  string      `json:"error,omitempty"`
-	ErrCauses interface{} `json:"err_causes,omitempty"`
+	ErrStatus     int                           `json:"status,omitempty"`
+	ErrError      string                        `json:"error,omitempty"`
+	ErrCauses     interface{}                   `json:"err_causes,omitempty"`
+	ErrViolations []domainErrors.FieldViolation `json:"violations,omitempty"`
+	ErrResource   *domainErrors.Resource        `json:"resource,omitempty"`
+	ErrRetryAfter int                           `json:"retry_after,omitempty"`
 }
 
 // ErrBody Error body
@@ -156,11 +168,80 @@ func NewInternalServerError(causes interface{}) RestErr {
 	return result
 }
 
+// NewDomainRestError New Rest Error of domain error, status matches gRPC code of the same error
+func NewDomainRestError(domainErr *domainErrors.Error, causes interface{}) RestErr {
+	return RestError{
+		ErrStatus:     grpcErrors.MapGRPCErrCodeToHttpStatus(grpcErrors.MapDomainKindToCode(domainErr.Kind)),
+		ErrError:      domainErr.Message,
+		ErrViolations: domainErr.Violations,
+		ErrResource:   domainErr.Resource,
+		ErrCauses:     causes,
+		ErrRetryAfter: int(math.Ceil(domainErr.RetryAfter.Seconds())),
+	}
+}
+
+// NewStatusRestError New Rest Error of gRPC status, status details made by grpcErrors.ErrorResponse are mapped
+// to the same body as ParseErrors returns for the error
+func NewStatusRestError(st *status.Status) RestErr {
+	restErr := RestError{
+		ErrStatus: grpcErrors.MapGRPCErrCodeToHttpStatus(st.Code()),
+		ErrError:  http.StatusText(grpcErrors.MapGRPCErrCodeToHttpStatus(st.Code())),
+		ErrCauses: st.Message(),
+	}
+	if st.Code() == codes.Internal || st.Code() == codes.Unknown {
+		restErr.ErrCauses = nil
+	}
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.LocalizedMessage:
+			restErr.ErrError = d.GetMessage()
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				restErr.ErrViolations = append(restErr.ErrViolations, domainErrors.FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		case *errdetails.ResourceInfo:
+			// resource identifies missing or conflicting entity, causes are not exposed
+			restErr.ErrResource = &domainErrors.Resource{Type: d.GetResourceType(), Name: d.GetResourceName()}
+			restErr.ErrCauses = nil
+		case *errdetails.RetryInfo:
+			restErr.ErrRetryAfter = int(math.Ceil(d.GetRetryDelay().AsDuration().Seconds()))
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() == grpcErrors.SMTPErrorDomain {
+				return NewRestError(http.StatusBadGateway, ErrMailDelivery, deliveryErrorFromMetadata(d.GetMetadata()))
+			}
+		}
+	}
+
+	return restErr
+}
+
+func deliveryErrorFromMetadata(metadata map[string]string) *models.DeliveryError {
+	code, _ := strconv.Atoi(metadata["code"])
+	return &models.DeliveryError{
+		Class:        metadata["class"],
+		Code:         code,
+		EnhancedCode: metadata["enhancedCode"],
+		Message:      metadata["message"],
+	}
+}
+
 // ParseErrors parse error string messages and returns RestError
 func ParseErrors(err error) RestErr {
+	var sendErr *smtp.SendError
+	if errors.As(err, &sendErr) {
+		return NewRestError(http.StatusBadGateway, ErrMailDelivery, sendErr.DeliveryError())
+	}
+
+	if domainErr := domainErrors.From(err); domainErr != nil {
+		// resource identifies missing or conflicting entity, internal errors causes are not exposed
+		if domainErr.Resource != nil || domainErr.Kind == domainErrors.Internal {
+			return NewDomainRestError(domainErr, nil)
+		}
+		return NewDomainRestError(domainErr, err.Error())
+	}
+
 	switch {
-	case errors.Is(err, sql.ErrNoRows):
-		return NewRestError(http.StatusNotFound, ErrNotFound, nil)
 	case errors.Is(err, context.DeadlineExceeded):
 		return NewRestError(http.StatusRequestTimeout, ErrRequestTimeout, nil)
 	case errors.Is(err, Unauthorized):
@@ -169,8 +250,6 @@ func ParseErrors(err error) RestErr {
 		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, nil)
 	case strings.Contains(strings.ToLower(err.Error()), "sqlstate"):
 		return parseSqlErrors(err)
-	case strings.Contains(strings.ToLower(err.Error()), "field validation"):
-		return parseValidatorError(err)
 	case strings.Contains(strings.ToLower(err.Error()), "unmarshal"):
 		return NewRestError(http.StatusBadRequest, ErrBadRequest, err)
 	case strings.Contains(strings.ToLower(err.Error()), "uuid"):
@@ -193,20 +272,21 @@ func parseSqlErrors(err error) RestErr {
 	return NewRestError(http.StatusBadRequest, ErrBadRequest, err)
 }
 
-func parseValidatorError(err error) RestErr {
-	if strings.Contains(err.Error(), "Password") {
-		return NewRestError(http.StatusBadRequest, ErrInvalidPassword, err)
-	}
-
-	if strings.Contains(err.Error(), "Email") {
-		return NewRestError(http.StatusBadRequest, ErrInvalidEmail, err)
-	}
-
-	return NewRestError(http.StatusBadRequest, ErrInvalidField, err)
-}
-
 // ErrorCtxResponse response with error status and code
 func ErrorCtxResponse(ctx echo.Context, err error) error {
 	restErr := ParseErrors(err)
+	if retryAfter := restErr.ErrBody().ErrRetryAfter; retryAfter > 0 {
+		ctx.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
 	return ctx.JSON(restErr.Status(), restErr.ErrBody())
 }
+
+// ErrorResponse write rest error response to http response writer, used by handlers not served by echo
+func ErrorResponse(w http.ResponseWriter, restErr RestErr) error {
+	if retryAfter := restErr.ErrBody().ErrRetryAfter; retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	w.WriteHeader(restErr.Status())
+	return json.NewEncoder(w).Encode(restErr.ErrBody())
+}
@@ -0,0 +1,45 @@
+package httpErrors
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	grpcErrors "github.com/AleksK1NG/nats-streaming/pkg/grpc_errors"
+	"github.com/AleksK1NG/nats-streaming/pkg/smtp"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewStatusRestError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "field error", err: domainErrors.NewFieldError("email_id", errors.New("invalid uuid"))},
+		{name: "not found", err: domainErrors.NewNotFound("email", "42", errors.New("no rows"))},
+		{name: "unavailable", err: domainErrors.NewUnavailable("Mail queue is unavailable", 1500*time.Millisecond, errors.New("nats"))},
+		{name: "smtp", err: smtp.NewSendError(errors.New("550 5.1.1 mailbox unavailable"))},
+		{name: "internal", err: errors.New("connection refused")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := ParseErrors(tt.err).ErrBody()
+			got := NewStatusRestError(status.Convert(grpcErrors.ErrorResponse(tt.err, "emailUC.Create"))).ErrBody()
+
+			// gRPC status message is prefixed by handler, so causes are compared only when they are not exposed
+			if want.ErrCauses == nil || want.ErrStatus == http.StatusBadGateway {
+				if !reflect.DeepEqual(got.ErrCauses, want.ErrCauses) {
+					t.Errorf("causes = %#v, want %#v", got.ErrCauses, want.ErrCauses)
+				}
+			}
+			got.ErrCauses, want.ErrCauses = nil, nil
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("rest error = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
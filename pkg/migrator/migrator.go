@@ -0,0 +1,341 @@
+package migrator
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/pkg/logger"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+)
+
+const (
+	// advisoryLockID lock key shared by all replicas running migrations
+	advisoryLockID = 7270937466281051
+
+	createSchemaTableQuery = `CREATE TABLE IF NOT EXISTS schema_versions
+	(
+		version    BIGINT PRIMARY KEY,
+		name       VARCHAR(250)             NOT NULL,
+		applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+
+	appliedVersionsQuery = `SELECT version, applied_at FROM schema_versions ORDER BY version`
+	insertVersionQuery   = `INSERT INTO schema_versions (version, name) VALUES ($1, $2)`
+	deleteVersionQuery   = `DELETE FROM schema_versions WHERE version = $1`
+
+	// legacyVersionQuery current version of golang-migrate schema table if migrations were applied with migrate cli
+	legacyVersionQuery = `SELECT version FROM schema_migrations WHERE NOT dirty LIMIT 1`
+	legacyTableQuery   = `SELECT to_regclass('schema_migrations') IS NOT NULL`
+)
+
+var fileNameRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+var (
+	ErrNoMigrations   = errors.New("No migrations found")
+	ErrUnknownVersion = errors.New("Unknown migration version")
+)
+
+// Migration single versioned migration
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus migration with applied state
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Status migrations status
+type Status struct {
+	Version    int64
+	Migrations []*MigrationStatus
+}
+
+// Migrator versioned postgresql migrations runner
+type Migrator struct {
+	db         *pgxpool.Pool
+	log        logger.Logger
+	migrations []*Migration
+}
+
+// NewMigrator migrator constructor, migrations are loaded from <version>_<name>.<up|down>.sql files of fsys root
+func NewMigrator(db *pgxpool.Pool, log logger.Logger, fsys fs.FS) (*Migrator, error) {
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		return nil, errors.Wrap(err, "loadMigrations")
+	}
+	return &Migrator{db: db, log: log, migrations: migrations}, nil
+}
+
+// Up apply all pending migrations
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down rollback last applied migration
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *pgxpool.Conn, applied map[int64]time.Time) error {
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			if _, ok := applied[m.migrations[i].Version]; ok {
+				return m.rollback(ctx, conn, m.migrations[i])
+			}
+		}
+		m.log.Info("No migrations to rollback")
+		return nil
+	})
+}
+
+// To migrate up or down to given version, version 0 rollbacks all migrations
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	if version != 0 && m.find(version) == nil {
+		return errors.Wrapf(ErrUnknownVersion, "version: %d", version)
+	}
+
+	return m.withLock(ctx, func(conn *pgxpool.Conn, applied map[int64]time.Time) error {
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; ok && migration.Version > version {
+				if err := m.rollback(ctx, conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; !ok && migration.Version <= version {
+				if err := m.apply(ctx, conn, migration); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Status returns all migrations with applied state
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	status := &Status{Migrations: make([]*MigrationStatus, 0, len(m.migrations))}
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn, applied map[int64]time.Time) error {
+		for _, migration := range m.migrations {
+			ms := &MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if appliedAt, ok := applied[migration.Version]; ok {
+				ms.Applied = true
+				ms.AppliedAt = &appliedAt
+				status.Version = migration.Version
+			}
+			status.Migrations = append(status.Migrations, ms)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// withLock run fn holding session advisory lock so concurrently starting replicas don't race
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn, applied map[int64]time.Time) error) error {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return errors.Wrap(err, "db.Acquire")
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", advisoryLockID); err != nil {
+		return errors.Wrap(err, "pg_advisory_lock")
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockID); err != nil {
+			m.log.Errorf("pg_advisory_unlock: %v", err)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, createSchemaTableQuery); err != nil {
+		return errors.Wrap(err, "createSchemaTable")
+	}
+
+	applied, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return errors.Wrap(err, "appliedVersions")
+	}
+
+	return fn(conn, applied)
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.Query(ctx, appliedVersionsQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.Query")
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, errors.Wrap(err, "rows.Scan")
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows.Err")
+	}
+
+	if len(applied) == 0 {
+		return m.importLegacyVersion(ctx, conn)
+	}
+
+	return applied, nil
+}
+
+// importLegacyVersion mark migrations applied by golang-migrate cli as applied
+func (m *Migrator) importLegacyVersion(ctx context.Context, conn *pgxpool.Conn) (map[int64]time.Time, error) {
+	applied := make(map[int64]time.Time)
+
+	var exists bool
+	if err := conn.QueryRow(ctx, legacyTableQuery).Scan(&exists); err != nil {
+		return nil, errors.Wrap(err, "legacyTableQuery")
+	}
+	if !exists {
+		return applied, nil
+	}
+
+	var legacyVersion int64
+	if err := conn.QueryRow(ctx, legacyVersionQuery).Scan(&legacyVersion); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return applied, nil
+		}
+		return nil, errors.Wrap(err, "legacyVersionQuery")
+	}
+
+	now := time.Now().UTC()
+	for _, migration := range m.migrations {
+		if migration.Version > legacyVersion {
+			break
+		}
+		if _, err := conn.Exec(ctx, insertVersionQuery, migration.Version, migration.Name); err != nil {
+			return nil, errors.Wrap(err, "insertVersionQuery")
+		}
+		applied[migration.Version] = now
+	}
+
+	m.log.Infof("Imported golang-migrate schema version: %d", legacyVersion)
+	return applied, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, migration *Migration) error {
+	m.log.Infof("Applying migration: %d_%s", migration.Version, migration.Name)
+	return m.inTx(ctx, conn, migration.Up, insertVersionQuery, migration.Version, migration.Name)
+}
+
+func (m *Migrator) rollback(ctx context.Context, conn *pgxpool.Conn, migration *Migration) error {
+	m.log.Infof("Rolling back migration: %d_%s", migration.Version, migration.Name)
+	return m.inTx(ctx, conn, migration.Down, deleteVersionQuery, migration.Version)
+}
+
+// inTx run migration sql and schema table update in single transaction
+func (m *Migrator) inTx(ctx context.Context, conn *pgxpool.Conn, sql string, versionQuery string, args ...interface{}) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "conn.Begin")
+	}
+	defer tx.Rollback(ctx) // nolint: errcheck
+
+	// without arguments pgx uses simple protocol which allows multiple statements
+	if _, err := tx.Exec(ctx, sql); err != nil {
+		return errors.Wrapf(err, "migration: %v", args[0])
+	}
+	if _, err := tx.Exec(ctx, versionQuery, args...); err != nil {
+		return errors.Wrap(err, "versionQuery")
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (m *Migrator) find(version int64) *Migration {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration
+		}
+	}
+	return nil
+}
+
+func loadMigrations(fsys fs.FS) ([]*Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, errors.Wrap(err, "fs.Glob")
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, file := range files {
+		match := fileNameRegexp.FindStringSubmatch(path.Base(file))
+		if match == nil {
+			return nil, errors.Errorf("invalid migration file name: %s", file)
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "strconv.ParseInt")
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, errors.Wrap(err, "fs.ReadFile")
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, errors.Errorf("duplicate migration version: %d", version)
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	if len(byVersion) == 0 {
+		return nil, ErrNoMigrations
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, errors.Errorf("migration %d_%s must have up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+func (s *MigrationStatus) String() string {
+	if !s.Applied {
+		return fmt.Sprintf("%d_%s: pending", s.Version, s.Name)
+	}
+	return fmt.Sprintf("%d_%s: applied at %s", s.Version, s.Name, s.AppliedAt.Format(time.RFC3339))
+}
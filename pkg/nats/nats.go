@@ -9,11 +9,13 @@ import (
 )
 
 const (
-	connectWait        = time.Second * 30
-	pubAckWait         = time.Second * 30
-	interval           = 10
-	maxOut             = 5
-	maxPubAcksInflight = 25
+	connectWait = time.Second * 30
+	pubAckWait  = time.Second * 30
+	interval    = 10
+	maxOut      = 5
+
+	// MaxPubAcksInflight max number of published messages waiting for ack, async publish blocks above it
+	MaxPubAcksInflight = 25
 )
 
 func NewNatsConnect(cfg *config.Config, log logger.Logger) (stan.Conn, error) {
@@ -27,6 +29,6 @@ func NewNatsConnect(cfg *config.Config, log logger.Logger) (stan.Conn, error) {
 		stan.SetConnectionLostHandler(func(_ stan.Conn, reason error) {
 			log.Fatalf("Connection lost, reason: %v", reason)
 		}),
-		stan.MaxPubAcksInflight(maxPubAcksInflight),
+		stan.MaxPubAcksInflight(MaxPubAcksInflight),
 	)
 }
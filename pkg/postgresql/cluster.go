@@ -0,0 +1,157 @@
+package postgresql
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/config"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// replicaLagQuery returns if wal receiver is streaming, seconds since last message from primary and replay lag,
+// lag is zero if replica replayed all received wal, so idle primary is not reported as lag, wal receiver
+// status and last message time are visible only to roles with pg_read_all_stats, otherwise they are not checked
+const replicaLagQuery = `SELECT r.pid IS NOT NULL AND COALESCE(r.status, 'streaming') = 'streaming',
+	COALESCE(EXTRACT(EPOCH FROM now() - r.last_msg_receipt_time), 0),
+	CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END
+	FROM (SELECT 1) AS s LEFT JOIN pg_stat_wal_receiver AS r ON true`
+
+type primaryCtxKey struct{}
+
+// WithPrimary returns context which routes reads to primary, used for read your own writes paths
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryCtxKey{}, true)
+}
+
+func usePrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryCtxKey{}).(bool)
+	return primary
+}
+
+// Cluster primary pool for writes with read replicas pools, replicas lagging behind more than max lag,
+// disconnected from primary longer than receive timeout or failing lag check are not used for reads
+type Cluster struct {
+	primary        *pgxpool.Pool
+	replicas       []*replica
+	maxLag         time.Duration
+	receiveTimeout time.Duration
+	next           uint32
+}
+
+type replica struct {
+	name      string
+	pool      *pgxpool.Pool
+	available int32
+}
+
+// NewCluster connect to primary, replicas are connected lazily and are unavailable until first lag check,
+// so unreachable replica doesn't prevent start and reads fallback to primary
+func NewCluster(cfg *config.Config) (*Cluster, error) {
+	ctx := context.Background()
+
+	primary, err := NewPgxConn(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewPgxConn")
+	}
+
+	c := &Cluster{
+		primary:        primary,
+		maxLag:         cfg.PostgreSQL.MaxReplicaLag * time.Second,
+		receiveTimeout: cfg.PostgreSQL.ReplicaReceiveTimeout * time.Second,
+	}
+	for _, dataSourceName := range cfg.PostgreSQL.Replicas {
+		pool, err := newPool(ctx, dataSourceName, true)
+		if err != nil {
+			c.Close()
+			return nil, errors.Wrap(err, "replica newPool")
+		}
+		c.replicas = append(c.replicas, &replica{name: pool.Config().ConnConfig.Host, pool: pool})
+		replicaAvailable.WithLabelValues(pool.Config().ConnConfig.Host).Set(0)
+	}
+
+	return c, nil
+}
+
+// Primary returns primary pool
+func (c *Cluster) Primary() *pgxpool.Pool {
+	return c.primary
+}
+
+// Reader returns next available replica pool in round robin order,
+// primary if context requires primary or there is no available replica
+func (c *Cluster) Reader(ctx context.Context) *pgxpool.Pool {
+	if len(c.replicas) == 0 || usePrimary(ctx) {
+		return c.primary
+	}
+
+	start := atomic.AddUint32(&c.next, 1)
+	for i := 0; i < len(c.replicas); i++ {
+		r := c.replicas[(int(start)+i)%len(c.replicas)]
+		if atomic.LoadInt32(&r.available) == 1 {
+			return r.pool
+		}
+	}
+
+	replicaFallbacks.Inc()
+	return c.primary
+}
+
+// MonitorLag check replicas lag every interval until context is done
+func (c *Cluster) MonitorLag(ctx context.Context, interval time.Duration) {
+	if len(c.replicas) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, r := range c.replicas {
+			c.checkLag(ctx, r)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Cluster) checkLag(ctx context.Context, r *replica) {
+	ctx, cancel := context.WithTimeout(ctx, c.maxLag+time.Second)
+	defer cancel()
+
+	var (
+		streaming              bool
+		receiveAge, lagSeconds float64
+	)
+	if err := r.pool.QueryRow(ctx, replicaLagQuery).Scan(&streaming, &receiveAge, &lagSeconds); err != nil {
+		atomic.StoreInt32(&r.available, 0)
+		replicaAvailable.WithLabelValues(r.name).Set(0)
+		return
+	}
+
+	replicaLag.WithLabelValues(r.name).Set(lagSeconds)
+	// replica which lost connection to primary has nothing to replay, so its lag is zero
+	disconnected := !streaming || (c.receiveTimeout > 0 && time.Duration(receiveAge*float64(time.Second)) > c.receiveTimeout)
+	if disconnected || time.Duration(lagSeconds*float64(time.Second)) > c.maxLag {
+		atomic.StoreInt32(&r.available, 0)
+		replicaAvailable.WithLabelValues(r.name).Set(0)
+		return
+	}
+
+	atomic.StoreInt32(&r.available, 1)
+	replicaAvailable.WithLabelValues(r.name).Set(1)
+}
+
+// Close close primary and replicas pools
+func (c *Cluster) Close() {
+	for _, r := range c.replicas {
+		r.pool.Close()
+	}
+	c.primary.Close()
+}
@@ -0,0 +1,21 @@
+package postgresql
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	replicaLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "postgresql_replica_lag_seconds",
+		Help: "The last measured replication lag of PostgreSQL read replica",
+	}, []string{"replica"})
+	replicaAvailable = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "postgresql_replica_available",
+		Help: "Whether PostgreSQL read replica is used for reads",
+	}, []string{"replica"})
+	replicaFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "postgresql_replica_fallbacks_total",
+		Help: "The total number of reads routed to primary because no replica was available",
+	})
+)
@@ -16,10 +16,9 @@ const (
 	maxConnIdleTime   = 1 * time.Minute
 	maxConnLifetime   = 3 * time.Minute
 	minConns          = 10
-	lazyConnect       = false
 )
 
-// NewPgxConn pool
+// NewPgxConn primary pool
 func NewPgxConn(cfg *config.Config) (*pgxpool.Pool, error) {
 	ctx := context.Background()
 	dataSourceName := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=%s password=%s",
@@ -31,6 +30,11 @@ func NewPgxConn(cfg *config.Config) (*pgxpool.Pool, error) {
 		cfg.PostgreSQL.PostgresqlPassword,
 	)
 
+	return newPool(ctx, dataSourceName, false)
+}
+
+// newPool connect pool, lazy pool doesn't connect until first use, so unreachable database doesn't fail it
+func newPool(ctx context.Context, dataSourceName string, lazyConnect bool) (*pgxpool.Pool, error) {
 	poolCfg, err := pgxpool.ParseConfig(dataSourceName)
 	if err != nil {
 		return nil, err
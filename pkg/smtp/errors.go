@@ -0,0 +1,145 @@
+package smtp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/AleksK1NG/nats-streaming/internal/models"
+)
+
+const (
+	ErrorClassPermanent = "permanent"
+	ErrorClassTransient = "transient"
+)
+
+// enhancedCodeRegexp RFC 3463 enhanced mail system status code at the beginning of reply text
+var enhancedCodeRegexp = regexp.MustCompile(`^([245]\.\d{1,3}\.\d{1,3})\s*`)
+
+// SendError structured SMTP send error with reply code and RFC 3463 enhanced status code
+type SendError struct {
+	Class        string
+	Code         int
+	EnhancedCode string
+	Message      string
+	Err          error
+}
+
+// transientMessages go-simple-mail connection and timeout errors, which are returned without original error
+var transientMessages = []string{
+	"Mail Error on dailing",
+	"Mail Error on smtp dial",
+	"SMTP Connection timed out",
+	"SMTP Send timed out",
+	"No SMTP Client Provided",
+}
+
+// NewSendError parse SMTP reply of mail server error, errors without reply like timeouts
+// and network errors are transient, other errors without reply are client side validation
+// errors like invalid address or missing recipient, so they are permanent
+func NewSendError(err error) *SendError {
+	sendErr := &SendError{Class: ErrorClassPermanent, Message: err.Error(), Err: err}
+
+	var tpErr *textproto.Error
+	if !errors.As(err, &tpErr) {
+		if isTransientError(err) {
+			sendErr.Class = ErrorClassTransient
+		}
+		return sendErr
+	}
+
+	sendErr.Code = tpErr.Code
+	sendErr.Message = tpErr.Msg
+	if match := enhancedCodeRegexp.FindStringSubmatch(tpErr.Msg); match != nil {
+		sendErr.EnhancedCode = match[1]
+		sendErr.Message = tpErr.Msg[len(match[0]):]
+	}
+
+	if tpErr.Code < 500 || tpErr.Code >= 600 {
+		sendErr.Class = ErrorClassTransient
+	}
+
+	return sendErr
+}
+
+// NewConnectError parse error of connecting to mail server, errors without reply are transient
+func NewConnectError(err error) *SendError {
+	sendErr := NewSendError(err)
+	if sendErr.Code == 0 {
+		sendErr.Class = ErrorClassTransient
+	}
+	return sendErr
+}
+
+// AsSendError returns SendError from error chain, errors which are not returned by mail client,
+// e.g. database errors, are transient
+func AsSendError(err error) *SendError {
+	var sendErr *SendError
+	if errors.As(err, &sendErr) {
+		return sendErr
+	}
+	return &SendError{Class: ErrorClassTransient, Message: err.Error(), Err: err}
+}
+
+func isTransientError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+	for _, msg := range transientMessages {
+		if strings.Contains(err.Error(), msg) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *SendError) Error() string {
+	if e.Code == 0 {
+		return fmt.Sprintf("smtp %s error: %s", e.Class, e.Message)
+	}
+	return fmt.Sprintf("smtp %s error: %d %s %s", e.Class, e.Code, e.EnhancedCode, e.Message)
+}
+
+func (e *SendError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent returns true if mail server rejected email and retry will not succeed
+func (e *SendError) Permanent() bool {
+	return e.Class == ErrorClassPermanent
+}
+
+// CodeLabel reply code as string, empty for errors without reply
+func (e *SendError) CodeLabel() string {
+	if e.Code == 0 {
+		return ""
+	}
+	return strconv.Itoa(e.Code)
+}
+
+// DeliveryError convert send error to delivery error model
+func (e *SendError) DeliveryError() *models.DeliveryError {
+	return &models.DeliveryError{
+		Class:        e.Class,
+		Code:         e.Code,
+		EnhancedCode: e.EnhancedCode,
+		Message:      e.Message,
+	}
+}
+
+// IsPermanentError returns true if mail server rejected email with permanent 5xx reply code or email
+// failed client side validation, other errors like 4xx replies, timeouts and network errors are transient
+func IsPermanentError(err error) bool {
+	return AsSendError(err).Permanent()
+}
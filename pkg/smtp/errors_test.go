@@ -0,0 +1,88 @@
+package smtp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"testing"
+)
+
+func TestNewSendError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		class        string
+		code         int
+		enhancedCode string
+	}{
+		{
+			name:         "permanent reply",
+			err:          &textproto.Error{Code: 550, Msg: "5.1.1 user unknown"},
+			class:        ErrorClassPermanent,
+			code:         550,
+			enhancedCode: "5.1.1",
+		},
+		{
+			name:         "transient reply",
+			err:          fmt.Errorf("send: %w", &textproto.Error{Code: 451, Msg: "4.7.1 try again later"}),
+			class:        ErrorClassTransient,
+			code:         451,
+			enhancedCode: "4.7.1",
+		},
+		{
+			name:  "network error",
+			err:   &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			class: ErrorClassTransient,
+		},
+		{
+			name:  "send timeout",
+			err:   errors.New("Mail Error: SMTP Send timed out"),
+			class: ErrorClassTransient,
+		},
+		{
+			name:  "invalid address",
+			err:   errors.New("Mail Error: mail: missing '@' or angle-addr; Header: [To] Address: [invalid]"),
+			class: ErrorClassPermanent,
+		},
+		{
+			name:  "no recipient",
+			err:   errors.New("Mail Error: No recipient specified"),
+			class: ErrorClassPermanent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sendErr := NewSendError(tt.err)
+			if sendErr.Class != tt.class {
+				t.Errorf("class = %s, want %s", sendErr.Class, tt.class)
+			}
+			if sendErr.Code != tt.code {
+				t.Errorf("code = %d, want %d", sendErr.Code, tt.code)
+			}
+			if sendErr.EnhancedCode != tt.enhancedCode {
+				t.Errorf("enhanced code = %s, want %s", sendErr.EnhancedCode, tt.enhancedCode)
+			}
+		})
+	}
+}
+
+func TestNewConnectError(t *testing.T) {
+	if sendErr := NewConnectError(errors.New("Mail Error on dailing with encryption type TLS: EOF")); sendErr.Permanent() {
+		t.Errorf("connect error without reply is permanent")
+	}
+	if sendErr := NewConnectError(&textproto.Error{Code: 535, Msg: "5.7.8 authentication failed"}); !sendErr.Permanent() {
+		t.Errorf("connect error with 5xx reply is transient")
+	}
+}
+
+func TestAsSendError(t *testing.T) {
+	sendErr := NewSendError(errors.New("Mail Error: No recipient specified"))
+	if got := AsSendError(fmt.Errorf("SendMail: %w", sendErr)); got != sendErr {
+		t.Errorf("AsSendError returned %v, want %v", got, sendErr)
+	}
+	if IsPermanentError(errors.New("db.QueryRow: connection reset")) {
+		t.Errorf("error not returned by mail client is permanent")
+	}
+}
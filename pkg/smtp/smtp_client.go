@@ -1,7 +1,10 @@
 package smtp
 
 import (
+	"context"
 	"crypto/tls"
+	"net"
+	"strconv"
 	"time"
 
 	"github.com/AleksK1NG/nats-streaming/config"
@@ -12,6 +15,8 @@ import (
 // SMTPClient interface
 type SMTPClient interface {
 	SendMail(mail *models.MailData) error
+	Relay() string
+	Ping(ctx context.Context) error
 }
 
 type smtpClient struct {
@@ -42,11 +47,26 @@ func (s *smtpClient) getConn() (*mail.SMTPClient, error) {
 	return server.Connect()
 }
 
-// SendMail send simple email with text message
+// Relay returns address of mail server used to send emails
+func (s *smtpClient) Relay() string {
+	return net.JoinHostPort(s.cfg.MailService.Host, strconv.Itoa(s.cfg.MailService.Port))
+}
+
+// Ping check mail server is reachable by opening TCP connection to it
+func (s *smtpClient) Ping(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.Relay())
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// SendMail send simple email with text message, returns SendError on failure
 func (s *smtpClient) SendMail(mailData *models.MailData) error {
 	conn, err := s.getConn()
 	if err != nil {
-		return err
+		return NewConnectError(err)
 	}
 	defer conn.Close()
 
@@ -56,5 +76,8 @@ func (s *smtpClient) SendMail(mailData *models.MailData) error {
 	msg.SetSubject(mailData.Subject)
 	msg.SetBody(mail.TextPlain, mailData.Content)
 
-	return msg.Send(conn)
+	if err := msg.Send(conn); err != nil {
+		return NewSendError(err)
+	}
+	return nil
 }
@@ -0,0 +1,263 @@
+package tsquery
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	"github.com/pkg/errors"
+)
+
+const maxQueryLength = 512
+
+var ErrInvalidQuery = domainErrors.New(domainErrors.InvalidArgument, "Invalid search query")
+
+// Parse compile user search input to postgresql tsquery.
+// Supported syntax: words, "quoted phrases", AND, OR, NOT, -word, (groups) and word* prefix matching,
+// terms without operator between them are joined with AND. Returns empty string for blank input.
+func Parse(input string) (string, error) {
+	if len(input) > maxQueryLength {
+		return "", errors.Wrapf(ErrInvalidQuery, "query is longer than %d bytes", maxQueryLength)
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return "", err
+	}
+	if len(tokens) == 0 {
+		return "", nil
+	}
+
+	p := &parser{tokens: tokens}
+	query, _, err := p.parseOr()
+	if err != nil {
+		return "", err
+	}
+	if !p.done() {
+		return "", p.unexpected()
+	}
+
+	return query, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenPhrase
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func tokenize(input string) ([]token, error) {
+	tokens := make([]token, 0)
+
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", pos: i})
+			i += size
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", pos: i})
+			i += size
+		case r == '-':
+			tokens = append(tokens, token{kind: tokenNot, value: "-", pos: i})
+			i += size
+		case r == '"':
+			end := strings.IndexRune(input[i+1:], '"')
+			if end < 0 {
+				return nil, errors.Wrapf(ErrInvalidQuery, "unterminated quote at position %d", i)
+			}
+			phrase := strings.TrimSpace(input[i+1 : i+1+end])
+			if phrase == "" {
+				return nil, errors.Wrapf(ErrInvalidQuery, "empty phrase at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenPhrase, value: phrase, pos: i})
+			i += end + 2
+		default:
+			start := i
+			for i < len(input) {
+				r, size := utf8.DecodeRuneInString(input[i:])
+				if unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, wordToken(input[start:i], start))
+		}
+	}
+
+	return tokens, nil
+}
+
+func wordToken(word string, pos int) token {
+	switch word {
+	case "AND":
+		return token{kind: tokenAnd, value: word, pos: pos}
+	case "OR":
+		return token{kind: tokenOr, value: word, pos: pos}
+	case "NOT":
+		return token{kind: tokenNot, value: word, pos: pos}
+	default:
+		return token{kind: tokenWord, value: word, pos: pos}
+	}
+}
+
+// parser recursive descent parser, NOT binds tighter than AND, AND binds tighter than OR
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) unexpected() error {
+	if p.done() {
+		return errors.Wrap(ErrInvalidQuery, "unexpected end of query")
+	}
+	t := p.peek()
+	return errors.Wrapf(ErrInvalidQuery, "unexpected %q at position %d", t.value, t.pos)
+}
+
+// parseOr returns conjunction true when query is several AND operands without enclosing parentheses
+func (p *parser) parseOr() (query string, conjunction bool, err error) {
+	left, conjunction, err := p.parseAnd()
+	if err != nil {
+		return "", false, err
+	}
+
+	operands := []string{left}
+	for !p.done() && p.peek().kind == tokenOr {
+		p.pos++
+		right, _, err := p.parseAnd()
+		if err != nil {
+			return "", false, err
+		}
+		operands = append(operands, right)
+	}
+
+	if len(operands) == 1 {
+		return left, conjunction, nil
+	}
+	return "(" + strings.Join(operands, " | ") + ")", false, nil
+}
+
+func (p *parser) parseAnd() (query string, conjunction bool, err error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return "", false, err
+	}
+
+	operands := []string{left}
+	for !p.done() {
+		switch p.peek().kind {
+		case tokenAnd:
+			p.pos++
+		case tokenOr, tokenRParen:
+			return strings.Join(operands, " & "), len(operands) > 1, nil
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return "", false, err
+		}
+		operands = append(operands, right)
+	}
+
+	return strings.Join(operands, " & "), len(operands) > 1, nil
+}
+
+func (p *parser) parseUnary() (string, error) {
+	if !p.done() && p.peek().kind == tokenNot {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return "", err
+		}
+		return "!" + operand, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (string, error) {
+	if p.done() {
+		return "", p.unexpected()
+	}
+
+	t := p.peek()
+	switch t.kind {
+	case tokenWord:
+		p.pos++
+		return lexeme(t.value)
+	case tokenPhrase:
+		p.pos++
+		return phrase(t.value)
+	case tokenLParen:
+		p.pos++
+		group, conjunction, err := p.parseOr()
+		if err != nil {
+			return "", err
+		}
+		if p.done() || p.peek().kind != tokenRParen {
+			return "", errors.Wrapf(ErrInvalidQuery, "unclosed parenthesis at position %d", t.pos)
+		}
+		p.pos++
+		// group keeps its parentheses, so negation applies to whole group
+		if conjunction {
+			return "(" + group + ")", nil
+		}
+		return group, nil
+	default:
+		return "", p.unexpected()
+	}
+}
+
+func phrase(value string) (string, error) {
+	words := strings.Fields(value)
+	lexemes := make([]string, 0, len(words))
+	for _, word := range words {
+		l, err := lexeme(word)
+		if err != nil {
+			return "", err
+		}
+		lexemes = append(lexemes, l)
+	}
+	if len(lexemes) == 1 {
+		return lexemes[0], nil
+	}
+	return "(" + strings.Join(lexemes, " <-> ") + ")", nil
+}
+
+// lexeme quote word as tsquery lexeme, so it can not be interpreted as operator, trailing * means prefix match
+func lexeme(word string) (string, error) {
+	prefix := strings.HasSuffix(word, "*")
+	word = strings.TrimRight(word, "*")
+	if word == "" {
+		return "", errors.Wrap(ErrInvalidQuery, "prefix match requires at least one character")
+	}
+
+	quoted := "'" + strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(word) + "'"
+	if prefix {
+		return quoted + ":*", nil
+	}
+	return quoted, nil
+}
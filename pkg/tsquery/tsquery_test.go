@@ -0,0 +1,89 @@
+package tsquery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty", input: "", want: ""},
+		{name: "blank", input: " \t ", want: ""},
+		{name: "word", input: "invoice", want: "'invoice'"},
+		{name: "implicit and", input: "invoice paid", want: "'invoice' & 'paid'"},
+		{name: "explicit and", input: "invoice AND paid", want: "'invoice' & 'paid'"},
+		{name: "or", input: "invoice OR receipt", want: "('invoice' | 'receipt')"},
+		{name: "and binds tighter than or", input: "a b OR c", want: "('a' & 'b' | 'c')"},
+		{name: "not", input: "NOT spam", want: "!'spam'"},
+		{name: "minus", input: "invoice -spam", want: "'invoice' & !'spam'"},
+		{name: "double not", input: "NOT -spam", want: "!!'spam'"},
+		{name: "phrase", input: `"quick brown fox"`, want: "('quick' <-> 'brown' <-> 'fox')"},
+		{name: "single word phrase", input: `" quick "`, want: "'quick'"},
+		{name: "prefix", input: "deliv*", want: "'deliv':*"},
+		{name: "prefix in phrase", input: `"order conf*"`, want: "('order' <-> 'conf':*)"},
+		{name: "group", input: "(invoice OR receipt) paid", want: "('invoice' | 'receipt') & 'paid'"},
+		{name: "nested group", input: "-(a (b OR c))", want: "!('a' & ('b' | 'c'))"},
+		{name: "negated and group", input: "invoice -(spam promo)", want: "'invoice' & !('spam' & 'promo')"},
+		{name: "negated or group", input: "-(spam OR promo)", want: "!('spam' | 'promo')"},
+		{name: "lowercase operators are words", input: "cats or dogs", want: "'cats' & 'or' & 'dogs'"},
+		{name: "quote is escaped", input: "it's", want: "'it''s'"},
+		{name: "backslash is escaped", input: `back\slash`, want: `'back\\slash'`},
+		{name: "tsquery operators are quoted", input: "a&b|c:* !d", want: "'a&b|c:':* & '!d'"},
+		{name: "dash inside word", input: "e-mail", want: "'e-mail'"},
+		{name: "max length", input: strings.Repeat("a", maxQueryLength), want: "'" + strings.Repeat("a", maxQueryLength) + "'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{name: "too long", input: strings.Repeat("a", maxQueryLength+1), message: "longer than"},
+		{name: "unterminated quote", input: `"quick brown`, message: "unterminated quote at position 0"},
+		{name: "empty phrase", input: `invoice "  "`, message: "empty phrase at position 8"},
+		{name: "trailing and", input: "invoice AND", message: "unexpected end of query"},
+		{name: "trailing not", input: "invoice NOT", message: "unexpected end of query"},
+		{name: "leading or", input: "OR invoice", message: `unexpected "OR" at position 0`},
+		{name: "double or", input: "a OR OR b", message: `unexpected "OR" at position 5`},
+		{name: "unmatched closing parenthesis", input: "a )", message: `unexpected ")" at position 2`},
+		{name: "unclosed parenthesis", input: "(a OR b", message: "unclosed parenthesis at position 0"},
+		{name: "empty group", input: "()", message: `unexpected ")" at position 1`},
+		{name: "bare prefix", input: "*", message: "prefix match requires at least one character"},
+		{name: "bare prefix in phrase", input: `"order *"`, message: "prefix match requires at least one character"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err == nil {
+				t.Fatalf("Parse(%q) = %q, want error", tt.input, got)
+			}
+			if !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("Parse(%q) error %v is not ErrInvalidQuery", tt.input, err)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Parse(%q) error %q does not contain %q", tt.input, err.Error(), tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	"github.com/pkg/errors"
+)
+
+var ErrInvalidCursor = domainErrors.New(domainErrors.InvalidArgument, "Invalid cursor")
+
+// EncodeCursor encode keyset pagination position to opaque url safe cursor
+func EncodeCursor(position interface{}) (string, error) {
+	positionBytes, err := json.Marshal(position)
+	if err != nil {
+		return "", errors.Wrap(err, "json.Marshal")
+	}
+	return base64.RawURLEncoding.EncodeToString(positionBytes), nil
+}
+
+// DecodeCursor decode opaque cursor to keyset pagination position
+func DecodeCursor(cursor string, position interface{}) error {
+	positionBytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return errors.Wrap(ErrInvalidCursor, err.Error())
+	}
+	if err := json.Unmarshal(positionBytes, position); err != nil {
+		return errors.Wrap(ErrInvalidCursor, err.Error())
+	}
+	return nil
+}
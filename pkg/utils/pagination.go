@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+
+	domainErrors "github.com/AleksK1NG/nats-streaming/pkg/domain_errors"
+	"github.com/pkg/errors"
 )
 
 const (
 	defaultSize = 10
+	maxSize     = 100
 )
 
 // Pagination query params
@@ -17,12 +21,22 @@ type Pagination struct {
 	OrderBy string `json:"orderBy,omitempty"`
 }
 
-// NewPaginationQuery Pagination query constructor
-func NewPaginationQuery(size int, page int) *Pagination {
-	return &Pagination{Size: size, Page: page}
+// NewPaginationQuery Pagination query constructor, default size is used if size is zero (not set),
+// size is capped at max size, negative size and page are invalid
+func NewPaginationQuery(size int, page int) (*Pagination, error) {
+	q := &Pagination{Size: defaultSize}
+	if size != 0 {
+		if err := q.setSize(size); err != nil {
+			return nil, err
+		}
+	}
+	if err := q.setPage(page); err != nil {
+		return nil, err
+	}
+	return q, nil
 }
 
-// SetSize Set page size
+// SetSize Set page size, default size is used if size query is empty, size is capped at max size
 func (q *Pagination) SetSize(sizeQuery string) error {
 	if sizeQuery == "" {
 		q.Size = defaultSize
@@ -30,25 +44,40 @@ func (q *Pagination) SetSize(sizeQuery string) error {
 	}
 	n, err := strconv.Atoi(sizeQuery)
 	if err != nil {
-		return err
+		return domainErrors.NewFieldError("size", err)
 	}
-	q.Size = n
-
-	return nil
+	return q.setSize(n)
 }
 
 // SetPage Set page number
 func (q *Pagination) SetPage(pageQuery string) error {
 	if pageQuery == "" {
-		q.Size = 0
+		q.Page = 0
 		return nil
 	}
 	n, err := strconv.Atoi(pageQuery)
 	if err != nil {
-		return err
+		return domainErrors.NewFieldError("page", err)
 	}
-	q.Page = n
+	return q.setPage(n)
+}
 
+func (q *Pagination) setSize(size int) error {
+	if size <= 0 {
+		return domainErrors.NewFieldError("size", errors.Errorf("must be positive, got %d", size))
+	}
+	if size > maxSize {
+		size = maxSize
+	}
+	q.Size = size
+	return nil
+}
+
+func (q *Pagination) setPage(page int) error {
+	if page < 0 {
+		return domainErrors.NewFieldError("page", errors.Errorf("must not be negative, got %d", page))
+	}
+	q.Page = page
 	return nil
 }
 
@@ -92,12 +121,17 @@ func (q *Pagination) GetQueryString() string {
 
 // GetTotalPages Get total pages int
 func (q *Pagination) GetTotalPages(totalCount int) int {
-	// d := float64(totalCount) / float64(pageSize)
+	if q.GetSize() <= 0 {
+		return 0
+	}
 	d := float64(totalCount) / float64(q.GetSize())
 	return int(math.Ceil(d))
 }
 
 // GetHasMore Get has more
 func (q *Pagination) GetHasMore(totalCount int) bool {
+	if q.GetSize() <= 0 {
+		return false
+	}
 	return q.GetPage() < totalCount/q.GetSize()
 }
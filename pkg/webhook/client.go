@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/AleksK1NG/nats-streaming/config"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrForbiddenScheme  = errors.New("Webhook url scheme is not allowed")
+	ErrForbiddenAddress = errors.New("Webhook url address is not allowed")
+)
+
+// privateNetworks ranges which are not routable from internet, loopback, link local and multicast are checked by net.IP
+var privateNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+	"fc00::/7",
+)
+
+// AddressPolicy allowed webhook urls, by default only https urls of public addresses are allowed
+type AddressPolicy struct {
+	AllowHTTP            bool
+	AllowPrivateNetworks bool
+}
+
+// NewAddressPolicy webhook address policy constructor
+func NewAddressPolicy(cfg config.Webhooks) AddressPolicy {
+	return AddressPolicy{AllowHTTP: cfg.AllowHTTP, AllowPrivateNetworks: cfg.AllowPrivateNetworks}
+}
+
+// ValidateURL check scheme and host of webhook url, host names are checked again when they are resolved on dial
+func (p AddressPolicy) ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrap(err, "url.Parse")
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "https":
+	case "http":
+		if !p.AllowHTTP {
+			return errors.Wrapf(ErrForbiddenScheme, "scheme: %s", u.Scheme)
+		}
+	default:
+		return errors.Wrapf(ErrForbiddenScheme, "scheme: %s", u.Scheme)
+	}
+
+	if u.User != nil {
+		return errors.Wrap(ErrForbiddenAddress, "user info")
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return errors.Wrap(ErrForbiddenAddress, "host is required")
+	}
+	if p.AllowPrivateNetworks {
+		return nil
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.Wrapf(ErrForbiddenAddress, "host: %s", host)
+	}
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return errors.Wrapf(ErrForbiddenAddress, "host: %s", host)
+	}
+	return nil
+}
+
+// NewHTTPClient returns http client for webhook deliveries, redirects are not followed, proxy from environment is not used
+// and connections to addresses not allowed by policy are refused after host name is resolved
+func NewHTTPClient(timeout time.Duration, policy AddressPolicy) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !policy.AllowPrivateNetworks {
+		dialer.Control = controlPublicAddress
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   timeout,
+			ExpectContinueTimeout: time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// IsPublicIP returns false for loopback, private, link local, multicast and other not routable addresses
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func controlPublicAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrap(err, "net.SplitHostPort")
+	}
+	if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+		return errors.Wrapf(ErrForbiddenAddress, "address: %s", host)
+	}
+	return nil
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SignatureHeader header of webhook request signature in format t=<unix timestamp>,v1=<hex hmac>
+	SignatureHeader = "X-Webhook-Signature"
+	// EventHeader header of webhook request event type
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader header of webhook request delivery id, it is the same for all attempts of delivery
+	DeliveryHeader = "X-Webhook-Delivery"
+
+	secretBytes = 32
+)
+
+var ErrInvalidSignature = errors.New("Invalid webhook signature")
+
+// Sign returns signature header value, HMAC-SHA256 is computed over "<unix timestamp>.<body>"
+// so receivers can reject replayed requests
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := timestamp.Unix()
+	return fmt.Sprintf("t=%d,v1=%s", unix, hex.EncodeToString(computeMAC(secret, unix, body)))
+}
+
+// Verify check signature header value of body, signatures older than tolerance are rejected if tolerance is set
+func Verify(secret string, header string, body []byte, tolerance time.Duration) error {
+	var (
+		unix      int64
+		signature []byte
+		err       error
+	)
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			return errors.Wrapf(ErrInvalidSignature, "malformed part: %s", part)
+		}
+		switch kv[0] {
+		case "t":
+			if unix, err = strconv.ParseInt(kv[1], 10, 64); err != nil {
+				return errors.Wrapf(ErrInvalidSignature, "timestamp: %v", err)
+			}
+		case "v1":
+			if signature, err = hex.DecodeString(kv[1]); err != nil {
+				return errors.Wrapf(ErrInvalidSignature, "signature: %v", err)
+			}
+		}
+	}
+	if unix == 0 || signature == nil {
+		return errors.Wrap(ErrInvalidSignature, "timestamp and signature are required")
+	}
+	if tolerance > 0 && time.Since(time.Unix(unix, 0)) > tolerance {
+		return errors.Wrap(ErrInvalidSignature, "timestamp is out of tolerance")
+	}
+	if !hmac.Equal(signature, computeMAC(secret, unix, body)) {
+		return errors.Wrap(ErrInvalidSignature, "signature mismatch")
+	}
+	return nil
+}
+
+// GenerateSecret returns random hex encoded webhook secret
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "rand.Read")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func computeMAC(secret string, unix int64, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(unix, 10))) // nolint: errcheck
+	mac.Write([]byte("."))                         // nolint: errcheck
+	mac.Write(body)                                // nolint: errcheck
+	return mac.Sum(nil)
+}
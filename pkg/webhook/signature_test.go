@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	const secret = "0123456789abcdef"
+	body := []byte(`{"sequence":1,"type":"sent"}`)
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		secret    string
+		header    string
+		body      []byte
+		tolerance time.Duration
+		wantErr   bool
+	}{
+		{name: "valid", secret: secret, header: Sign(secret, now, body), body: body, tolerance: time.Minute},
+		{name: "valid without tolerance", secret: secret, header: Sign(secret, now.Add(-time.Hour), body), body: body},
+		{name: "out of tolerance", secret: secret, header: Sign(secret, now.Add(-time.Hour), body), body: body, tolerance: time.Minute, wantErr: true},
+		{name: "tampered body", secret: secret, header: Sign(secret, now, body), body: []byte(`{"sequence":1,"type":"failed"}`), wantErr: true},
+		{name: "wrong secret", secret: "fedcba9876543210", header: Sign(secret, now, body), body: body, wantErr: true},
+		{
+			name:    "tampered timestamp",
+			secret:  secret,
+			header:  strings.Replace(Sign(secret, now, body), fmt.Sprintf("t=%d", now.Unix()), fmt.Sprintf("t=%d", now.Unix()+1), 1),
+			body:    body,
+			wantErr: true,
+		},
+		{name: "missing signature", secret: secret, header: fmt.Sprintf("t=%d", now.Unix()), body: body, wantErr: true},
+		{name: "malformed header", secret: secret, header: "signature", body: body, wantErr: true},
+		{name: "invalid hex", secret: secret, header: fmt.Sprintf("t=%d,v1=zz", now.Unix()), body: body, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header, tt.body, tt.tolerance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("Verify() error = %v, want %v", err, ErrInvalidSignature)
+			}
+		})
+	}
+}
@@ -1,22 +1,23 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.25.0
+// 	protoc-gen-go v1.26.0
 // 	protoc        v3.15.4
 // source: email.proto
 
-//protoc --go_out=plugins=grpc:. *.proto
+//protoc -I . -I $GATEWAY_DIR/third_party/googleapis --go_out=plugins=grpc:. --grpc-gateway_out=. --openapiv2_out=. *.proto
 
 package emailService
 
 import (
 	context "context"
-	proto "github.com/golang/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -28,10 +29,6 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// This is a compile-time assertion that a sufficiently up-to-date version
-// of the legacy proto package is being used.
-const _ = proto.ProtoPackageIsVersion4
-
 type Email struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -43,6 +40,16 @@ type Email struct {
 	Subject   string                 `protobuf:"bytes,4,opt,name=Subject,proto3" json:"Subject,omitempty"`
 	Message   string                 `protobuf:"bytes,5,opt,name=Message,proto3" json:"Message,omitempty"`
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	Priority  string                 `protobuf:"bytes,7,opt,name=Priority,proto3" json:"Priority,omitempty"`
+	Status    string                 `protobuf:"bytes,8,opt,name=Status,proto3" json:"Status,omitempty"`
+	Attempts  int64                  `protobuf:"varint,9,opt,name=Attempts,proto3" json:"Attempts,omitempty"`
+	LastError *DeliveryError         `protobuf:"bytes,10,opt,name=LastError,proto3" json:"LastError,omitempty"`
+	Rank      float32                `protobuf:"fixed32,11,opt,name=Rank,proto3" json:"Rank,omitempty"`
+	// html escaped message fragment with matched terms wrapped by highlight markers
+	Snippet  string `protobuf:"bytes,12,opt,name=Snippet,proto3" json:"Snippet,omitempty"`
+	Language string `protobuf:"bytes,13,opt,name=Language,proto3" json:"Language,omitempty"`
+	TenantID string `protobuf:"bytes,14,opt,name=TenantID,proto3" json:"TenantID,omitempty"`
+	Version  int64  `protobuf:"varint,15,opt,name=Version,proto3" json:"Version,omitempty"`
 }
 
 func (x *Email) Reset() {
@@ -119,6 +126,140 @@ func (x *Email) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Email) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *Email) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Email) GetAttempts() int64 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Email) GetLastError() *DeliveryError {
+	if x != nil {
+		return x.LastError
+	}
+	return nil
+}
+
+func (x *Email) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Email) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *Email) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Email) GetTenantID() string {
+	if x != nil {
+		return x.TenantID
+	}
+	return ""
+}
+
+func (x *Email) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeliveryError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Class        string `protobuf:"bytes,1,opt,name=Class,proto3" json:"Class,omitempty"`
+	Code         int64  `protobuf:"varint,2,opt,name=Code,proto3" json:"Code,omitempty"`
+	EnhancedCode string `protobuf:"bytes,3,opt,name=EnhancedCode,proto3" json:"EnhancedCode,omitempty"`
+	Message      string `protobuf:"bytes,4,opt,name=Message,proto3" json:"Message,omitempty"`
+}
+
+func (x *DeliveryError) Reset() {
+	*x = DeliveryError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryError) ProtoMessage() {}
+
+func (x *DeliveryError) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryError.ProtoReflect.Descriptor instead.
+func (*DeliveryError) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeliveryError) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *DeliveryError) GetCode() int64 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *DeliveryError) GetEnhancedCode() string {
+	if x != nil {
+		return x.EnhancedCode
+	}
+	return ""
+}
+
+func (x *DeliveryError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 type Empty struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -128,7 +269,7 @@ type Empty struct {
 func (x *Empty) Reset() {
 	*x = Empty{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_email_proto_msgTypes[1]
+		mi := &file_email_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -141,7 +282,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[1]
+	mi := &file_email_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -154,7 +295,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{1}
+	return file_email_proto_rawDescGZIP(), []int{2}
 }
 
 type CreateReq struct {
@@ -162,16 +303,19 @@ type CreateReq struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	From    string `protobuf:"bytes,1,opt,name=From,proto3" json:"From,omitempty"`
-	To      string `protobuf:"bytes,2,opt,name=To,proto3" json:"To,omitempty"`
-	Subject string `protobuf:"bytes,3,opt,name=Subject,proto3" json:"Subject,omitempty"`
-	Message string `protobuf:"bytes,4,opt,name=Message,proto3" json:"Message,omitempty"`
+	From     string `protobuf:"bytes,1,opt,name=From,proto3" json:"From,omitempty"`
+	To       string `protobuf:"bytes,2,opt,name=To,proto3" json:"To,omitempty"`
+	Subject  string `protobuf:"bytes,3,opt,name=Subject,proto3" json:"Subject,omitempty"`
+	Message  string `protobuf:"bytes,4,opt,name=Message,proto3" json:"Message,omitempty"`
+	Priority string `protobuf:"bytes,5,opt,name=Priority,proto3" json:"Priority,omitempty"`
+	Language string `protobuf:"bytes,6,opt,name=Language,proto3" json:"Language,omitempty"`
+	TenantID string `protobuf:"bytes,7,opt,name=TenantID,proto3" json:"TenantID,omitempty"`
 }
 
 func (x *CreateReq) Reset() {
 	*x = CreateReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_email_proto_msgTypes[2]
+		mi := &file_email_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -184,7 +328,7 @@ func (x *CreateReq) String() string {
 func (*CreateReq) ProtoMessage() {}
 
 func (x *CreateReq) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[2]
+	mi := &file_email_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -197,7 +341,7 @@ func (x *CreateReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateReq.ProtoReflect.Descriptor instead.
 func (*CreateReq) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{2}
+	return file_email_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreateReq) GetFrom() string {
@@ -228,6 +372,27 @@ func (x *CreateReq) GetMessage() string {
 	return ""
 }
 
+func (x *CreateReq) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *CreateReq) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *CreateReq) GetTenantID() string {
+	if x != nil {
+		return x.TenantID
+	}
+	return ""
+}
+
 type CreateRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -239,7 +404,7 @@ type CreateRes struct {
 func (x *CreateRes) Reset() {
 	*x = CreateRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_email_proto_msgTypes[3]
+		mi := &file_email_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -252,7 +417,7 @@ func (x *CreateRes) String() string {
 func (*CreateRes) ProtoMessage() {}
 
 func (x *CreateRes) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[3]
+	mi := &file_email_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -265,7 +430,7 @@ func (x *CreateRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRes.ProtoReflect.Descriptor instead.
 func (*CreateRes) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{3}
+	return file_email_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateRes) GetStatus() string {
@@ -286,7 +451,7 @@ type GetByIDReq struct {
 func (x *GetByIDReq) Reset() {
 	*x = GetByIDReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_email_proto_msgTypes[4]
+		mi := &file_email_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -299,7 +464,7 @@ func (x *GetByIDReq) String() string {
 func (*GetByIDReq) ProtoMessage() {}
 
 func (x *GetByIDReq) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[4]
+	mi := &file_email_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -312,7 +477,7 @@ func (x *GetByIDReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetByIDReq.ProtoReflect.Descriptor instead.
 func (*GetByIDReq) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{4}
+	return file_email_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetByIDReq) GetEmailID() string {
@@ -333,7 +498,7 @@ type GetByIDRes struct {
 func (x *GetByIDRes) Reset() {
 	*x = GetByIDRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_email_proto_msgTypes[5]
+		mi := &file_email_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -346,7 +511,7 @@ func (x *GetByIDRes) String() string {
 func (*GetByIDRes) ProtoMessage() {}
 
 func (x *GetByIDRes) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[5]
+	mi := &file_email_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -359,7 +524,7 @@ func (x *GetByIDRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetByIDRes.ProtoReflect.Descriptor instead.
 func (*GetByIDRes) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{5}
+	return file_email_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetByIDRes) GetEmail() *Email {
@@ -369,33 +534,35 @@ func (x *GetByIDRes) GetEmail() *Email {
 	return nil
 }
 
-type SearchReq struct {
+type UpdateReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Search string `protobuf:"bytes,1,opt,name=Search,proto3" json:"Search,omitempty"`
-	Page   int64  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	Size   int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	EmailID string                  `protobuf:"bytes,1,opt,name=EmailID,proto3" json:"EmailID,omitempty"`
+	Version int64                   `protobuf:"varint,2,opt,name=Version,proto3" json:"Version,omitempty"`
+	To      *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=To,proto3" json:"To,omitempty"`
+	Subject *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=Subject,proto3" json:"Subject,omitempty"`
+	Message *wrapperspb.StringValue `protobuf:"bytes,5,opt,name=Message,proto3" json:"Message,omitempty"`
 }
 
-func (x *SearchReq) Reset() {
-	*x = SearchReq{}
+func (x *UpdateReq) Reset() {
+	*x = UpdateReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_email_proto_msgTypes[6]
+		mi := &file_email_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SearchReq) String() string {
+func (x *UpdateReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchReq) ProtoMessage() {}
+func (*UpdateReq) ProtoMessage() {}
 
-func (x *SearchReq) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[6]
+func (x *UpdateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -406,62 +573,71 @@ func (x *SearchReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchReq.ProtoReflect.Descriptor instead.
-func (*SearchReq) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use UpdateReq.ProtoReflect.Descriptor instead.
+func (*UpdateReq) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *SearchReq) GetSearch() string {
+func (x *UpdateReq) GetEmailID() string {
 	if x != nil {
-		return x.Search
+		return x.EmailID
 	}
 	return ""
 }
 
-func (x *SearchReq) GetPage() int64 {
+func (x *UpdateReq) GetVersion() int64 {
 	if x != nil {
-		return x.Page
+		return x.Version
 	}
 	return 0
 }
 
-func (x *SearchReq) GetSize() int64 {
+func (x *UpdateReq) GetTo() *wrapperspb.StringValue {
 	if x != nil {
-		return x.Size
+		return x.To
 	}
-	return 0
+	return nil
 }
 
-type SearchRes struct {
+func (x *UpdateReq) GetSubject() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *UpdateReq) GetMessage() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type UpdateRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TotalCount int64    `protobuf:"varint,1,opt,name=TotalCount,proto3" json:"TotalCount,omitempty"`
-	TotalPages int64    `protobuf:"varint,2,opt,name=TotalPages,proto3" json:"TotalPages,omitempty"`
-	Page       int64    `protobuf:"varint,3,opt,name=Page,proto3" json:"Page,omitempty"`
-	Size       int64    `protobuf:"varint,4,opt,name=Size,proto3" json:"Size,omitempty"`
-	HasMore    bool     `protobuf:"varint,5,opt,name=HasMore,proto3" json:"HasMore,omitempty"`
-	Emails     []*Email `protobuf:"bytes,6,rep,name=Emails,proto3" json:"Emails,omitempty"`
+	Email *Email `protobuf:"bytes,1,opt,name=Email,proto3" json:"Email,omitempty"`
 }
 
-func (x *SearchRes) Reset() {
-	*x = SearchRes{}
+func (x *UpdateRes) Reset() {
+	*x = UpdateRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_email_proto_msgTypes[7]
+		mi := &file_email_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SearchRes) String() string {
+func (x *UpdateRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRes) ProtoMessage() {}
+func (*UpdateRes) ProtoMessage() {}
 
-func (x *SearchRes) ProtoReflect() protoreflect.Message {
-	mi := &file_email_proto_msgTypes[7]
+func (x *UpdateRes) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -472,51 +648,1177 @@ func (x *SearchRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRes.ProtoReflect.Descriptor instead.
-func (*SearchRes) Descriptor() ([]byte, []int) {
-	return file_email_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use UpdateRes.ProtoReflect.Descriptor instead.
+func (*UpdateRes) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *SearchRes) GetTotalCount() int64 {
+func (x *UpdateRes) GetEmail() *Email {
 	if x != nil {
-		return x.TotalCount
+		return x.Email
 	}
-	return 0
+	return nil
 }
 
-func (x *SearchRes) GetTotalPages() int64 {
+type SearchReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Search         string                 `protobuf:"bytes,1,opt,name=Search,proto3" json:"Search,omitempty"`
+	Page           int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size           int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Cursor         string                 `protobuf:"bytes,4,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	SkipTotalCount bool                   `protobuf:"varint,5,opt,name=SkipTotalCount,proto3" json:"SkipTotalCount,omitempty"`
+	From           string                 `protobuf:"bytes,6,opt,name=From,proto3" json:"From,omitempty"`
+	To             string                 `protobuf:"bytes,7,opt,name=To,proto3" json:"To,omitempty"`
+	Status         string                 `protobuf:"bytes,8,opt,name=Status,proto3" json:"Status,omitempty"`
+	SubjectPrefix  string                 `protobuf:"bytes,9,opt,name=SubjectPrefix,proto3" json:"SubjectPrefix,omitempty"`
+	CreatedFrom    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=CreatedFrom,proto3" json:"CreatedFrom,omitempty"`
+	CreatedTo      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=CreatedTo,proto3" json:"CreatedTo,omitempty"`
+	OrderBy        string                 `protobuf:"bytes,12,opt,name=OrderBy,proto3" json:"OrderBy,omitempty"`
+	Language       string                 `protobuf:"bytes,13,opt,name=Language,proto3" json:"Language,omitempty"`
+}
+
+func (x *SearchReq) Reset() {
+	*x = SearchReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchReq) ProtoMessage() {}
+
+func (x *SearchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchReq.ProtoReflect.Descriptor instead.
+func (*SearchReq) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchReq) GetSearch() string {
 	if x != nil {
-		return x.TotalPages
+		return x.Search
 	}
-	return 0
+	return ""
 }
 
-func (x *SearchRes) GetPage() int64 {
+func (x *SearchReq) GetPage() int64 {
 	if x != nil {
 		return x.Page
 	}
 	return 0
 }
 
-func (x *SearchRes) GetSize() int64 {
+func (x *SearchReq) GetSize() int64 {
 	if x != nil {
 		return x.Size
 	}
 	return 0
 }
 
-func (x *SearchRes) GetHasMore() bool {
+func (x *SearchReq) GetCursor() string {
 	if x != nil {
-		return x.HasMore
+		return x.Cursor
 	}
-	return false
+	return ""
 }
 
-func (x *SearchRes) GetEmails() []*Email {
+func (x *SearchReq) GetSkipTotalCount() bool {
 	if x != nil {
-		return x.Emails
+		return x.SkipTotalCount
 	}
-	return nil
+	return false
+}
+
+func (x *SearchReq) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SearchReq) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SearchReq) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SearchReq) GetSubjectPrefix() string {
+	if x != nil {
+		return x.SubjectPrefix
+	}
+	return ""
+}
+
+func (x *SearchReq) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *SearchReq) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *SearchReq) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *SearchReq) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type SearchRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TotalCount int64    `protobuf:"varint,1,opt,name=TotalCount,proto3" json:"TotalCount,omitempty"`
+	TotalPages int64    `protobuf:"varint,2,opt,name=TotalPages,proto3" json:"TotalPages,omitempty"`
+	Page       int64    `protobuf:"varint,3,opt,name=Page,proto3" json:"Page,omitempty"`
+	Size       int64    `protobuf:"varint,4,opt,name=Size,proto3" json:"Size,omitempty"`
+	HasMore    bool     `protobuf:"varint,5,opt,name=HasMore,proto3" json:"HasMore,omitempty"`
+	Emails     []*Email `protobuf:"bytes,6,rep,name=Emails,proto3" json:"Emails,omitempty"`
+	NextCursor string   `protobuf:"bytes,7,opt,name=NextCursor,proto3" json:"NextCursor,omitempty"`
+}
+
+func (x *SearchRes) Reset() {
+	*x = SearchRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRes) ProtoMessage() {}
+
+func (x *SearchRes) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRes.ProtoReflect.Descriptor instead.
+func (*SearchRes) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchRes) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SearchRes) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *SearchRes) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchRes) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SearchRes) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *SearchRes) GetEmails() []*Email {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+func (x *SearchRes) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ExportReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query  *SearchReq `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
+	Format string     `protobuf:"bytes,2,opt,name=Format,proto3" json:"Format,omitempty"`
+	Gzip   bool       `protobuf:"varint,3,opt,name=Gzip,proto3" json:"Gzip,omitempty"`
+}
+
+func (x *ExportReq) Reset() {
+	*x = ExportReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportReq) ProtoMessage() {}
+
+func (x *ExportReq) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportReq.ProtoReflect.Descriptor instead.
+func (*ExportReq) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ExportReq) GetQuery() *SearchReq {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *ExportReq) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportReq) GetGzip() bool {
+	if x != nil {
+		return x.Gzip
+	}
+	return false
+}
+
+type ExportChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=Data,proto3" json:"Data,omitempty"`
+}
+
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChunk) ProtoMessage() {}
+
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DeliveryAttempt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AttemptID  string                 `protobuf:"bytes,1,opt,name=AttemptID,proto3" json:"AttemptID,omitempty"`
+	EmailID    string                 `protobuf:"bytes,2,opt,name=EmailID,proto3" json:"EmailID,omitempty"`
+	Attempt    int64                  `protobuf:"varint,3,opt,name=Attempt,proto3" json:"Attempt,omitempty"`
+	WorkerID   string                 `protobuf:"bytes,4,opt,name=WorkerID,proto3" json:"WorkerID,omitempty"`
+	Relay      string                 `protobuf:"bytes,5,opt,name=Relay,proto3" json:"Relay,omitempty"`
+	Status     string                 `protobuf:"bytes,6,opt,name=Status,proto3" json:"Status,omitempty"`
+	StartedAt  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=StartedAt,proto3" json:"StartedAt,omitempty"`
+	FinishedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=FinishedAt,proto3" json:"FinishedAt,omitempty"`
+	Error      *DeliveryError         `protobuf:"bytes,9,opt,name=Error,proto3" json:"Error,omitempty"`
+}
+
+func (x *DeliveryAttempt) Reset() {
+	*x = DeliveryAttempt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeliveryAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryAttempt) ProtoMessage() {}
+
+func (x *DeliveryAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryAttempt.ProtoReflect.Descriptor instead.
+func (*DeliveryAttempt) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeliveryAttempt) GetAttemptID() string {
+	if x != nil {
+		return x.AttemptID
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetEmailID() string {
+	if x != nil {
+		return x.EmailID
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetAttempt() int64 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *DeliveryAttempt) GetWorkerID() string {
+	if x != nil {
+		return x.WorkerID
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetRelay() string {
+	if x != nil {
+		return x.Relay
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeliveryAttempt) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *DeliveryAttempt) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *DeliveryAttempt) GetError() *DeliveryError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type GetDeliveryHistoryReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EmailID string `protobuf:"bytes,1,opt,name=EmailID,proto3" json:"EmailID,omitempty"`
+}
+
+func (x *GetDeliveryHistoryReq) Reset() {
+	*x = GetDeliveryHistoryReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDeliveryHistoryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryHistoryReq) ProtoMessage() {}
+
+func (x *GetDeliveryHistoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryHistoryReq.ProtoReflect.Descriptor instead.
+func (*GetDeliveryHistoryReq) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetDeliveryHistoryReq) GetEmailID() string {
+	if x != nil {
+		return x.EmailID
+	}
+	return ""
+}
+
+type GetDeliveryHistoryRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EmailID  string             `protobuf:"bytes,1,opt,name=EmailID,proto3" json:"EmailID,omitempty"`
+	Attempts []*DeliveryAttempt `protobuf:"bytes,2,rep,name=Attempts,proto3" json:"Attempts,omitempty"`
+}
+
+func (x *GetDeliveryHistoryRes) Reset() {
+	*x = GetDeliveryHistoryRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDeliveryHistoryRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryHistoryRes) ProtoMessage() {}
+
+func (x *GetDeliveryHistoryRes) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryHistoryRes.ProtoReflect.Descriptor instead.
+func (*GetDeliveryHistoryRes) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetDeliveryHistoryRes) GetEmailID() string {
+	if x != nil {
+		return x.EmailID
+	}
+	return ""
+}
+
+func (x *GetDeliveryHistoryRes) GetAttempts() []*DeliveryAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+type EraseReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	Mode    string `protobuf:"bytes,2,opt,name=Mode,proto3" json:"Mode,omitempty"`
+}
+
+func (x *EraseReq) Reset() {
+	*x = EraseReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EraseReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseReq) ProtoMessage() {}
+
+func (x *EraseReq) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseReq.ProtoReflect.Descriptor instead.
+func (*EraseReq) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EraseReq) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *EraseReq) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type EraseRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErasureID    string                 `protobuf:"bytes,1,opt,name=ErasureID,proto3" json:"ErasureID,omitempty"`
+	Mode         string                 `protobuf:"bytes,2,opt,name=Mode,proto3" json:"Mode,omitempty"`
+	EmailIDs     []string               `protobuf:"bytes,3,rep,name=EmailIDs,proto3" json:"EmailIDs,omitempty"`
+	Emails       int64                  `protobuf:"varint,4,opt,name=Emails,proto3" json:"Emails,omitempty"`
+	Attempts     int64                  `protobuf:"varint,5,opt,name=Attempts,proto3" json:"Attempts,omitempty"`
+	DeadLetters  int64                  `protobuf:"varint,6,opt,name=DeadLetters,proto3" json:"DeadLetters,omitempty"`
+	CacheEvicted int64                  `protobuf:"varint,7,opt,name=CacheEvicted,proto3" json:"CacheEvicted,omitempty"`
+	ErasedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=ErasedAt,proto3" json:"ErasedAt,omitempty"`
+}
+
+func (x *EraseRes) Reset() {
+	*x = EraseRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EraseRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseRes) ProtoMessage() {}
+
+func (x *EraseRes) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseRes.ProtoReflect.Descriptor instead.
+func (*EraseRes) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EraseRes) GetErasureID() string {
+	if x != nil {
+		return x.ErasureID
+	}
+	return ""
+}
+
+func (x *EraseRes) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *EraseRes) GetEmailIDs() []string {
+	if x != nil {
+		return x.EmailIDs
+	}
+	return nil
+}
+
+func (x *EraseRes) GetEmails() int64 {
+	if x != nil {
+		return x.Emails
+	}
+	return 0
+}
+
+func (x *EraseRes) GetAttempts() int64 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *EraseRes) GetDeadLetters() int64 {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return 0
+}
+
+func (x *EraseRes) GetCacheEvicted() int64 {
+	if x != nil {
+		return x.CacheEvicted
+	}
+	return 0
+}
+
+func (x *EraseRes) GetErasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return nil
+}
+
+type CreateBatchReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Emails []*CreateReq `protobuf:"bytes,1,rep,name=Emails,proto3" json:"Emails,omitempty"`
+}
+
+func (x *CreateBatchReq) Reset() {
+	*x = CreateBatchReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateBatchReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBatchReq) ProtoMessage() {}
+
+func (x *CreateBatchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBatchReq.ProtoReflect.Descriptor instead.
+func (*CreateBatchReq) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateBatchReq) GetEmails() []*CreateReq {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+type BatchItemResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index   int64  `protobuf:"varint,1,opt,name=Index,proto3" json:"Index,omitempty"`
+	EmailID string `protobuf:"bytes,2,opt,name=EmailID,proto3" json:"EmailID,omitempty"`
+	Error   string `protobuf:"bytes,3,opt,name=Error,proto3" json:"Error,omitempty"`
+}
+
+func (x *BatchItemResult) Reset() {
+	*x = BatchItemResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItemResult) ProtoMessage() {}
+
+func (x *BatchItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItemResult.ProtoReflect.Descriptor instead.
+func (*BatchItemResult) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BatchItemResult) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetEmailID() string {
+	if x != nil {
+		return x.EmailID
+	}
+	return ""
+}
+
+func (x *BatchItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type CreateBatchRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Created int64              `protobuf:"varint,1,opt,name=Created,proto3" json:"Created,omitempty"`
+	Failed  int64              `protobuf:"varint,2,opt,name=Failed,proto3" json:"Failed,omitempty"`
+	Items   []*BatchItemResult `protobuf:"bytes,3,rep,name=Items,proto3" json:"Items,omitempty"`
+}
+
+func (x *CreateBatchRes) Reset() {
+	*x = CreateBatchRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateBatchRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBatchRes) ProtoMessage() {}
+
+func (x *CreateBatchRes) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBatchRes.ProtoReflect.Descriptor instead.
+func (*CreateBatchRes) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateBatchRes) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *CreateBatchRes) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *CreateBatchRes) GetItems() []*BatchItemResult {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CreateStreamRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accepted   int64              `protobuf:"varint,1,opt,name=Accepted,proto3" json:"Accepted,omitempty"`
+	Rejected   int64              `protobuf:"varint,2,opt,name=Rejected,proto3" json:"Rejected,omitempty"`
+	EmailIDs   []string           `protobuf:"bytes,3,rep,name=EmailIDs,proto3" json:"EmailIDs,omitempty"`
+	Rejections []*BatchItemResult `protobuf:"bytes,4,rep,name=Rejections,proto3" json:"Rejections,omitempty"`
+}
+
+func (x *CreateStreamRes) Reset() {
+	*x = CreateStreamRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateStreamRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateStreamRes) ProtoMessage() {}
+
+func (x *CreateStreamRes) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateStreamRes.ProtoReflect.Descriptor instead.
+func (*CreateStreamRes) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateStreamRes) GetAccepted() int64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *CreateStreamRes) GetRejected() int64 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *CreateStreamRes) GetEmailIDs() []string {
+	if x != nil {
+		return x.EmailIDs
+	}
+	return nil
+}
+
+func (x *CreateStreamRes) GetRejections() []*BatchItemResult {
+	if x != nil {
+		return x.Rejections
+	}
+	return nil
+}
+
+type WatchStatusReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EmailID       string   `protobuf:"bytes,1,opt,name=EmailID,proto3" json:"EmailID,omitempty"`
+	From          string   `protobuf:"bytes,2,opt,name=From,proto3" json:"From,omitempty"`
+	TenantID      string   `protobuf:"bytes,3,opt,name=TenantID,proto3" json:"TenantID,omitempty"`
+	AfterSequence uint64   `protobuf:"varint,4,opt,name=AfterSequence,proto3" json:"AfterSequence,omitempty"`
+	Types         []string `protobuf:"bytes,5,rep,name=Types,proto3" json:"Types,omitempty"`
+}
+
+func (x *WatchStatusReq) Reset() {
+	*x = WatchStatusReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchStatusReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStatusReq) ProtoMessage() {}
+
+func (x *WatchStatusReq) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStatusReq.ProtoReflect.Descriptor instead.
+func (*WatchStatusReq) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WatchStatusReq) GetEmailID() string {
+	if x != nil {
+		return x.EmailID
+	}
+	return ""
+}
+
+func (x *WatchStatusReq) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *WatchStatusReq) GetTenantID() string {
+	if x != nil {
+		return x.TenantID
+	}
+	return ""
+}
+
+func (x *WatchStatusReq) GetAfterSequence() uint64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+func (x *WatchStatusReq) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type StatusEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sequence   uint64                 `protobuf:"varint,1,opt,name=Sequence,proto3" json:"Sequence,omitempty"`
+	EmailID    string                 `protobuf:"bytes,2,opt,name=EmailID,proto3" json:"EmailID,omitempty"`
+	TenantID   string                 `protobuf:"bytes,3,opt,name=TenantID,proto3" json:"TenantID,omitempty"`
+	From       string                 `protobuf:"bytes,4,opt,name=From,proto3" json:"From,omitempty"`
+	To         string                 `protobuf:"bytes,5,opt,name=To,proto3" json:"To,omitempty"`
+	Status     string                 `protobuf:"bytes,6,opt,name=Status,proto3" json:"Status,omitempty"`
+	Attempts   int64                  `protobuf:"varint,7,opt,name=Attempts,proto3" json:"Attempts,omitempty"`
+	LastError  *DeliveryError         `protobuf:"bytes,8,opt,name=LastError,proto3" json:"LastError,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=OccurredAt,proto3" json:"OccurredAt,omitempty"`
+	Type       string                 `protobuf:"bytes,10,opt,name=Type,proto3" json:"Type,omitempty"`
+}
+
+func (x *StatusEvent) Reset() {
+	*x = StatusEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_email_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusEvent) ProtoMessage() {}
+
+func (x *StatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_email_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusEvent.ProtoReflect.Descriptor instead.
+func (*StatusEvent) Descriptor() ([]byte, []int) {
+	return file_email_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StatusEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *StatusEvent) GetEmailID() string {
+	if x != nil {
+		return x.EmailID
+	}
+	return ""
+}
+
+func (x *StatusEvent) GetTenantID() string {
+	if x != nil {
+		return x.TenantID
+	}
+	return ""
+}
+
+func (x *StatusEvent) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *StatusEvent) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *StatusEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StatusEvent) GetAttempts() int64 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *StatusEvent) GetLastError() *DeliveryError {
+	if x != nil {
+		return x.LastError
+	}
+	return nil
+}
+
+func (x *StatusEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *StatusEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
 var File_email_proto protoreflect.FileDescriptor
@@ -525,39 +1827,111 @@ var file_email_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x65,
 	0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb3, 0x01, 0x0a,
-	0x05, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49,
-	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44,
-	0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x46, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x02, 0x54, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x18,
-	0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x41, 0x74, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x63, 0x0a, 0x09, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02,
-	0x54, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x54, 0x6f, 0x12, 0x18, 0x0a, 0x07,
-	0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x53,
-	0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0x23, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x26, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44,
-	0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x22, 0x37, 0x0a,
-	0x0a, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x05, 0x45,
-	0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x6d, 0x61,
-	0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52,
-	0x05, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x4b, 0x0a, 0x09, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72,
+	0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbe, 0x03, 0x0a, 0x05, 0x45,
+	0x6d, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x12, 0x12,
+	0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x72,
+	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x54, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x12, 0x39, 0x0a, 0x09, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x09, 0x4c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x52,
+	0x61, 0x6e, 0x6b, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x52, 0x61, 0x6e, 0x6b, 0x12,
+	0x18, 0x0a, 0x07, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49,
+	0x44, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49,
+	0x44, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x77, 0x0a, 0x0d, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x43, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x43, 0x6c, 0x61,
+	0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x45, 0x6e, 0x68, 0x61, 0x6e, 0x63,
+	0x65, 0x64, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x45, 0x6e,
+	0x68, 0x61, 0x6e, 0x63, 0x65, 0x64, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0xb7, 0x01,
+	0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x46,
+	0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12,
+	0x0e, 0x0a, 0x02, 0x54, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x54, 0x6f, 0x12,
+	0x18, 0x0a, 0x07, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x54,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x54,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x44, 0x22, 0x23, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x26, 0x0a, 0x0a,
+	0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x45, 0x6d, 0x61,
+	0x69, 0x6c, 0x49, 0x44, 0x22, 0x37, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52,
+	0x65, 0x73, 0x12, 0x29, 0x0a, 0x05, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x05, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0xdd, 0x01,
+	0x0a, 0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x45,
+	0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x2c, 0x0a, 0x02, 0x54, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x02, 0x54, 0x6f, 0x12, 0x36, 0x0a,
+	0x07, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x53, 0x75,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x36, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x36, 0x0a,
+	0x09, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x05, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x05,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x9b, 0x03, 0x0a, 0x09, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
 	0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x70,
 	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12,
 	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73,
-	0x69, 0x7a, 0x65, 0x22, 0xba, 0x01, 0x0a, 0x09, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
+	0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x53,
+	0x6b, 0x69, 0x70, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x53, 0x6b, 0x69, 0x70, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x6f, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x54, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x24, 0x0a, 0x0d, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x3c, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x46, 0x72, 0x6f, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x46,
+	0x72, 0x6f, 0x6d, 0x12, 0x38, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x6f,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x6f, 0x12, 0x18, 0x0a,
+	0x07, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x22, 0xda, 0x01, 0x0a, 0x09, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
 	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e,
 	0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x18,
@@ -569,21 +1943,189 @@ var file_email_proto_rawDesc = []byte{
 	0x6f, 0x72, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x06, 0x20,
 	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
 	0x63, 0x65, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x06, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x73,
-	0x32, 0xcb, 0x01, 0x0a, 0x0c, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x3c, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x65, 0x6d,
-	0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12,
-	0x3f, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x12, 0x18, 0x2e, 0x65, 0x6d, 0x61,
-	0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49,
-	0x44, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x22, 0x00,
-	0x12, 0x3c, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x17, 0x2e, 0x65, 0x6d, 0x61,
-	0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x22, 0x00, 0x42, 0x10,
-	0x5a, 0x0e, 0x2e, 0x3b, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x1e, 0x0a, 0x0a, 0x4e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x4e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x22, 0x66, 0x0a, 0x09, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x12, 0x2d, 0x0a,
+	0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x52, 0x65, 0x71, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x47, 0x7a, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x47, 0x7a, 0x69, 0x70, 0x22, 0x21, 0x0a, 0x0b, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x22, 0xd6, 0x02, 0x0a, 0x0f,
+	0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x49, 0x44, 0x12, 0x18, 0x0a,
+	0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x44, 0x12, 0x14, 0x0a,
+	0x05, 0x52, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x52, 0x65,
+	0x6c, 0x61, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65,
+	0x64, 0x41, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x31, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0x31, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76,
+	0x65, 0x72, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a,
+	0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x22, 0x6c, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x44, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x12, 0x39, 0x0a, 0x08, 0x41, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x79, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x52, 0x08, 0x41, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x73, 0x22, 0x38, 0x0a, 0x08, 0x45, 0x72, 0x61, 0x73, 0x65, 0x52, 0x65,
+	0x71, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x4d,
+	0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x22,
+	0x8a, 0x02, 0x0a, 0x08, 0x45, 0x72, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x45, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x45, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x4d, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x45, 0x6d, 0x61, 0x69,
+	0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73,
+	0x12, 0x22, 0x0a, 0x0c, 0x43, 0x61, 0x63, 0x68, 0x65, 0x45, 0x76, 0x69, 0x63, 0x74, 0x65, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x43, 0x61, 0x63, 0x68, 0x65, 0x45, 0x76, 0x69,
+	0x63, 0x74, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x45, 0x72, 0x61, 0x73, 0x65, 0x64, 0x41, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x08, 0x45, 0x72, 0x61, 0x73, 0x65, 0x64, 0x41, 0x74, 0x22, 0x41, 0x0a, 0x0e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x12, 0x2f,
+	0x0a, 0x06, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x52, 0x06, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x22,
+	0x57, 0x0a, 0x0f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x45, 0x6d, 0x61, 0x69,
+	0x6c, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x77, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x05,
+	0x49, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x05, 0x49, 0x74, 0x65, 0x6d,
+	0x73, 0x22, 0xa4, 0x01, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x41, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x73, 0x12, 0x3d, 0x0a, 0x0a, 0x52, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0a, 0x52, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x96, 0x01, 0x0a, 0x0e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x45,
+	0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x54, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x41, 0x66,
+	0x74, 0x65, 0x72, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x54, 0x79, 0x70, 0x65,
+	0x73, 0x22, 0xc2, 0x02, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x65, 0x6e, 0x61, 0x6e,
+	0x74, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x54, 0x65, 0x6e, 0x61, 0x6e,
+	0x74, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x6f, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x54, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x39, 0x0a, 0x09, 0x4c,
+	0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x09, 0x4c, 0x61, 0x73,
+	0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x3a, 0x0a, 0x0a, 0x4f, 0x63, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x64, 0x41, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x4f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x32, 0xc6, 0x07, 0x0a, 0x0c, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x54, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x12, 0x17, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x3a, 0x01, 0x2a, 0x22, 0x0d,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x5e, 0x0a,
+	0x07, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x12, 0x18, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52,
+	0x65, 0x71, 0x1a, 0x18, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x73, 0x22, 0x1f, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x19, 0x12, 0x17, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x2f, 0x7b, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x7d, 0x12, 0x5e, 0x0a,
+	0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x1a, 0x17, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x1c, 0x3a, 0x01, 0x2a, 0x32, 0x17, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x2f, 0x7b, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x7d, 0x12, 0x58, 0x0a,
+	0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x17, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x1a, 0x17, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x16, 0x12, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x2f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x88, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x44,
+	0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x23,
+	0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65,
+	0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x1a, 0x23, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x22, 0x28, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22,
+	0x12, 0x20, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x2f,
+	0x7b, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x49, 0x44, 0x7d, 0x2f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x73, 0x12, 0x59, 0x0a, 0x05, 0x45, 0x72, 0x61, 0x73, 0x65, 0x12, 0x16, 0x2e, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x72, 0x61, 0x73, 0x65,
+	0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x45, 0x72, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x22, 0x20, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1a, 0x3a, 0x01, 0x2a, 0x22, 0x15, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x2f, 0x65, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x12, 0x69, 0x0a,
+	0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1c, 0x2e, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18,
+	0x3a, 0x01, 0x2a, 0x22, 0x13, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x2f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12, 0x4a, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x17, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x1d, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73,
+	0x22, 0x00, 0x28, 0x01, 0x12, 0x67, 0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x1a, 0x19, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x1d, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x32, 0x2f, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x2d, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x30, 0x01, 0x12, 0x40, 0x0a,
+	0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x17, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71,
+	0x1a, 0x19, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x42,
+	0x10, 0x5a, 0x0e, 0x2e, 0x3b, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -598,33 +2140,82 @@ func file_email_proto_rawDescGZIP() []byte {
 	return file_email_proto_rawDescData
 }
 
-var file_email_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_email_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_email_proto_goTypes = []interface{}{
-	(*Email)(nil),                 // 0: emailService.Email
-	(*Empty)(nil),                 // 1: emailService.Empty
-	(*CreateReq)(nil),             // 2: emailService.CreateReq
-	(*CreateRes)(nil),             // 3: emailService.CreateRes
-	(*GetByIDReq)(nil),            // 4: emailService.GetByIDReq
-	(*GetByIDRes)(nil),            // 5: emailService.GetByIDRes
-	(*SearchReq)(nil),             // 6: emailService.SearchReq
-	(*SearchRes)(nil),             // 7: emailService.SearchRes
-	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*Email)(nil),                  // 0: emailService.Email
+	(*DeliveryError)(nil),          // 1: emailService.DeliveryError
+	(*Empty)(nil),                  // 2: emailService.Empty
+	(*CreateReq)(nil),              // 3: emailService.CreateReq
+	(*CreateRes)(nil),              // 4: emailService.CreateRes
+	(*GetByIDReq)(nil),             // 5: emailService.GetByIDReq
+	(*GetByIDRes)(nil),             // 6: emailService.GetByIDRes
+	(*UpdateReq)(nil),              // 7: emailService.UpdateReq
+	(*UpdateRes)(nil),              // 8: emailService.UpdateRes
+	(*SearchReq)(nil),              // 9: emailService.SearchReq
+	(*SearchRes)(nil),              // 10: emailService.SearchRes
+	(*ExportReq)(nil),              // 11: emailService.ExportReq
+	(*ExportChunk)(nil),            // 12: emailService.ExportChunk
+	(*DeliveryAttempt)(nil),        // 13: emailService.DeliveryAttempt
+	(*GetDeliveryHistoryReq)(nil),  // 14: emailService.GetDeliveryHistoryReq
+	(*GetDeliveryHistoryRes)(nil),  // 15: emailService.GetDeliveryHistoryRes
+	(*EraseReq)(nil),               // 16: emailService.EraseReq
+	(*EraseRes)(nil),               // 17: emailService.EraseRes
+	(*CreateBatchReq)(nil),         // 18: emailService.CreateBatchReq
+	(*BatchItemResult)(nil),        // 19: emailService.BatchItemResult
+	(*CreateBatchRes)(nil),         // 20: emailService.CreateBatchRes
+	(*CreateStreamRes)(nil),        // 21: emailService.CreateStreamRes
+	(*WatchStatusReq)(nil),         // 22: emailService.WatchStatusReq
+	(*StatusEvent)(nil),            // 23: emailService.StatusEvent
+	(*timestamppb.Timestamp)(nil),  // 24: google.protobuf.Timestamp
+	(*wrapperspb.StringValue)(nil), // 25: google.protobuf.StringValue
 }
 var file_email_proto_depIdxs = []int32{
-	8, // 0: emailService.Email.CreatedAt:type_name -> google.protobuf.Timestamp
-	0, // 1: emailService.GetByIDRes.Email:type_name -> emailService.Email
-	0, // 2: emailService.SearchRes.Emails:type_name -> emailService.Email
-	2, // 3: emailService.EmailService.Create:input_type -> emailService.CreateReq
-	4, // 4: emailService.EmailService.GetByID:input_type -> emailService.GetByIDReq
-	6, // 5: emailService.EmailService.Search:input_type -> emailService.SearchReq
-	3, // 6: emailService.EmailService.Create:output_type -> emailService.CreateRes
-	5, // 7: emailService.EmailService.GetByID:output_type -> emailService.GetByIDRes
-	7, // 8: emailService.EmailService.Search:output_type -> emailService.SearchRes
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	24, // 0: emailService.Email.CreatedAt:type_name -> google.protobuf.Timestamp
+	1,  // 1: emailService.Email.LastError:type_name -> emailService.DeliveryError
+	0,  // 2: emailService.GetByIDRes.Email:type_name -> emailService.Email
+	25, // 3: emailService.UpdateReq.To:type_name -> google.protobuf.StringValue
+	25, // 4: emailService.UpdateReq.Subject:type_name -> google.protobuf.StringValue
+	25, // 5: emailService.UpdateReq.Message:type_name -> google.protobuf.StringValue
+	0,  // 6: emailService.UpdateRes.Email:type_name -> emailService.Email
+	24, // 7: emailService.SearchReq.CreatedFrom:type_name -> google.protobuf.Timestamp
+	24, // 8: emailService.SearchReq.CreatedTo:type_name -> google.protobuf.Timestamp
+	0,  // 9: emailService.SearchRes.Emails:type_name -> emailService.Email
+	9,  // 10: emailService.ExportReq.Query:type_name -> emailService.SearchReq
+	24, // 11: emailService.DeliveryAttempt.StartedAt:type_name -> google.protobuf.Timestamp
+	24, // 12: emailService.DeliveryAttempt.FinishedAt:type_name -> google.protobuf.Timestamp
+	1,  // 13: emailService.DeliveryAttempt.Error:type_name -> emailService.DeliveryError
+	13, // 14: emailService.GetDeliveryHistoryRes.Attempts:type_name -> emailService.DeliveryAttempt
+	24, // 15: emailService.EraseRes.ErasedAt:type_name -> google.protobuf.Timestamp
+	3,  // 16: emailService.CreateBatchReq.Emails:type_name -> emailService.CreateReq
+	19, // 17: emailService.CreateBatchRes.Items:type_name -> emailService.BatchItemResult
+	19, // 18: emailService.CreateStreamRes.Rejections:type_name -> emailService.BatchItemResult
+	1,  // 19: emailService.StatusEvent.LastError:type_name -> emailService.DeliveryError
+	24, // 20: emailService.StatusEvent.OccurredAt:type_name -> google.protobuf.Timestamp
+	3,  // 21: emailService.EmailService.Create:input_type -> emailService.CreateReq
+	5,  // 22: emailService.EmailService.GetByID:input_type -> emailService.GetByIDReq
+	7,  // 23: emailService.EmailService.Update:input_type -> emailService.UpdateReq
+	9,  // 24: emailService.EmailService.Search:input_type -> emailService.SearchReq
+	14, // 25: emailService.EmailService.GetDeliveryHistory:input_type -> emailService.GetDeliveryHistoryReq
+	16, // 26: emailService.EmailService.Erase:input_type -> emailService.EraseReq
+	18, // 27: emailService.EmailService.CreateBatch:input_type -> emailService.CreateBatchReq
+	3,  // 28: emailService.EmailService.CreateStream:input_type -> emailService.CreateReq
+	22, // 29: emailService.EmailService.WatchStatus:input_type -> emailService.WatchStatusReq
+	11, // 30: emailService.EmailService.Export:input_type -> emailService.ExportReq
+	4,  // 31: emailService.EmailService.Create:output_type -> emailService.CreateRes
+	6,  // 32: emailService.EmailService.GetByID:output_type -> emailService.GetByIDRes
+	8,  // 33: emailService.EmailService.Update:output_type -> emailService.UpdateRes
+	10, // 34: emailService.EmailService.Search:output_type -> emailService.SearchRes
+	15, // 35: emailService.EmailService.GetDeliveryHistory:output_type -> emailService.GetDeliveryHistoryRes
+	17, // 36: emailService.EmailService.Erase:output_type -> emailService.EraseRes
+	20, // 37: emailService.EmailService.CreateBatch:output_type -> emailService.CreateBatchRes
+	21, // 38: emailService.EmailService.CreateStream:output_type -> emailService.CreateStreamRes
+	23, // 39: emailService.EmailService.WatchStatus:output_type -> emailService.StatusEvent
+	12, // 40: emailService.EmailService.Export:output_type -> emailService.ExportChunk
+	31, // [31:41] is the sub-list for method output_type
+	21, // [21:31] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_email_proto_init() }
@@ -645,8 +2236,128 @@ func file_email_proto_init() {
 				return nil
 			}
 		}
-		file_email_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
+		file_email_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetByIDReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetByIDRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -657,8 +2368,8 @@ func file_email_proto_init() {
 				return nil
 			}
 		}
-		file_email_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateReq); i {
+		file_email_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportChunk); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -669,8 +2380,8 @@ func file_email_proto_init() {
 				return nil
 			}
 		}
-		file_email_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateRes); i {
+		file_email_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeliveryAttempt); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -681,8 +2392,8 @@ func file_email_proto_init() {
 				return nil
 			}
 		}
-		file_email_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetByIDReq); i {
+		file_email_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDeliveryHistoryReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -693,8 +2404,8 @@ func file_email_proto_init() {
 				return nil
 			}
 		}
-		file_email_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetByIDRes); i {
+		file_email_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDeliveryHistoryRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -705,8 +2416,8 @@ func file_email_proto_init() {
 				return nil
 			}
 		}
-		file_email_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchReq); i {
+		file_email_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EraseReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -717,8 +2428,80 @@ func file_email_proto_init() {
 				return nil
 			}
 		}
-		file_email_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchRes); i {
+		file_email_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EraseRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateBatchReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchItemResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateBatchRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateStreamRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchStatusReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_email_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -736,7 +2519,7 @@ func file_email_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_email_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -764,7 +2547,16 @@ const _ = grpc.SupportPackageIsVersion6
 type EmailServiceClient interface {
 	Create(ctx context.Context, in *CreateReq, opts ...grpc.CallOption) (*CreateRes, error)
 	GetByID(ctx context.Context, in *GetByIDReq, opts ...grpc.CallOption) (*GetByIDRes, error)
+	Update(ctx context.Context, in *UpdateReq, opts ...grpc.CallOption) (*UpdateRes, error)
 	Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchRes, error)
+	GetDeliveryHistory(ctx context.Context, in *GetDeliveryHistoryReq, opts ...grpc.CallOption) (*GetDeliveryHistoryRes, error)
+	Erase(ctx context.Context, in *EraseReq, opts ...grpc.CallOption) (*EraseRes, error)
+	CreateBatch(ctx context.Context, in *CreateBatchReq, opts ...grpc.CallOption) (*CreateBatchRes, error)
+	// CreateStream is client streaming, so it is served over gRPC only
+	CreateStream(ctx context.Context, opts ...grpc.CallOption) (EmailService_CreateStreamClient, error)
+	WatchStatus(ctx context.Context, in *WatchStatusReq, opts ...grpc.CallOption) (EmailService_WatchStatusClient, error)
+	// Export streams raw file chunks, so it is served over gRPC only
+	Export(ctx context.Context, in *ExportReq, opts ...grpc.CallOption) (EmailService_ExportClient, error)
 }
 
 type emailServiceClient struct {
@@ -793,6 +2585,15 @@ func (c *emailServiceClient) GetByID(ctx context.Context, in *GetByIDReq, opts .
 	return out, nil
 }
 
+func (c *emailServiceClient) Update(ctx context.Context, in *UpdateReq, opts ...grpc.CallOption) (*UpdateRes, error) {
+	out := new(UpdateRes)
+	err := c.cc.Invoke(ctx, "/emailService.EmailService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *emailServiceClient) Search(ctx context.Context, in *SearchReq, opts ...grpc.CallOption) (*SearchRes, error) {
 	out := new(SearchRes)
 	err := c.cc.Invoke(ctx, "/emailService.EmailService/Search", in, out, opts...)
@@ -802,11 +2603,145 @@ func (c *emailServiceClient) Search(ctx context.Context, in *SearchReq, opts ...
 	return out, nil
 }
 
+func (c *emailServiceClient) GetDeliveryHistory(ctx context.Context, in *GetDeliveryHistoryReq, opts ...grpc.CallOption) (*GetDeliveryHistoryRes, error) {
+	out := new(GetDeliveryHistoryRes)
+	err := c.cc.Invoke(ctx, "/emailService.EmailService/GetDeliveryHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) Erase(ctx context.Context, in *EraseReq, opts ...grpc.CallOption) (*EraseRes, error) {
+	out := new(EraseRes)
+	err := c.cc.Invoke(ctx, "/emailService.EmailService/Erase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) CreateBatch(ctx context.Context, in *CreateBatchReq, opts ...grpc.CallOption) (*CreateBatchRes, error) {
+	out := new(CreateBatchRes)
+	err := c.cc.Invoke(ctx, "/emailService.EmailService/CreateBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailServiceClient) CreateStream(ctx context.Context, opts ...grpc.CallOption) (EmailService_CreateStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EmailService_serviceDesc.Streams[0], "/emailService.EmailService/CreateStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &emailServiceCreateStreamClient{stream}
+	return x, nil
+}
+
+type EmailService_CreateStreamClient interface {
+	Send(*CreateReq) error
+	CloseAndRecv() (*CreateStreamRes, error)
+	grpc.ClientStream
+}
+
+type emailServiceCreateStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *emailServiceCreateStreamClient) Send(m *CreateReq) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *emailServiceCreateStreamClient) CloseAndRecv() (*CreateStreamRes, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(CreateStreamRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *emailServiceClient) WatchStatus(ctx context.Context, in *WatchStatusReq, opts ...grpc.CallOption) (EmailService_WatchStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EmailService_serviceDesc.Streams[1], "/emailService.EmailService/WatchStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &emailServiceWatchStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EmailService_WatchStatusClient interface {
+	Recv() (*StatusEvent, error)
+	grpc.ClientStream
+}
+
+type emailServiceWatchStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *emailServiceWatchStatusClient) Recv() (*StatusEvent, error) {
+	m := new(StatusEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *emailServiceClient) Export(ctx context.Context, in *ExportReq, opts ...grpc.CallOption) (EmailService_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EmailService_serviceDesc.Streams[2], "/emailService.EmailService/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &emailServiceExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EmailService_ExportClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type emailServiceExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *emailServiceExportClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EmailServiceServer is the server API for EmailService service.
 type EmailServiceServer interface {
 	Create(context.Context, *CreateReq) (*CreateRes, error)
 	GetByID(context.Context, *GetByIDReq) (*GetByIDRes, error)
+	Update(context.Context, *UpdateReq) (*UpdateRes, error)
 	Search(context.Context, *SearchReq) (*SearchRes, error)
+	GetDeliveryHistory(context.Context, *GetDeliveryHistoryReq) (*GetDeliveryHistoryRes, error)
+	Erase(context.Context, *EraseReq) (*EraseRes, error)
+	CreateBatch(context.Context, *CreateBatchReq) (*CreateBatchRes, error)
+	// CreateStream is client streaming, so it is served over gRPC only
+	CreateStream(EmailService_CreateStreamServer) error
+	WatchStatus(*WatchStatusReq, EmailService_WatchStatusServer) error
+	// Export streams raw file chunks, so it is served over gRPC only
+	Export(*ExportReq, EmailService_ExportServer) error
 }
 
 // UnimplementedEmailServiceServer can be embedded to have forward compatible implementations.
@@ -819,9 +2754,30 @@ func (*UnimplementedEmailServiceServer) Create(context.Context, *CreateReq) (*Cr
 func (*UnimplementedEmailServiceServer) GetByID(context.Context, *GetByIDReq) (*GetByIDRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByID not implemented")
 }
+func (*UnimplementedEmailServiceServer) Update(context.Context, *UpdateReq) (*UpdateRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
 func (*UnimplementedEmailServiceServer) Search(context.Context, *SearchReq) (*SearchRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (*UnimplementedEmailServiceServer) GetDeliveryHistory(context.Context, *GetDeliveryHistoryReq) (*GetDeliveryHistoryRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeliveryHistory not implemented")
+}
+func (*UnimplementedEmailServiceServer) Erase(context.Context, *EraseReq) (*EraseRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Erase not implemented")
+}
+func (*UnimplementedEmailServiceServer) CreateBatch(context.Context, *CreateBatchReq) (*CreateBatchRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBatch not implemented")
+}
+func (*UnimplementedEmailServiceServer) CreateStream(EmailService_CreateStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method CreateStream not implemented")
+}
+func (*UnimplementedEmailServiceServer) WatchStatus(*WatchStatusReq, EmailService_WatchStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStatus not implemented")
+}
+func (*UnimplementedEmailServiceServer) Export(*ExportReq, EmailService_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
 
 func RegisterEmailServiceServer(s *grpc.Server, srv EmailServiceServer) {
 	s.RegisterService(&_EmailService_serviceDesc, srv)
@@ -863,6 +2819,24 @@ func _EmailService_GetByID_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EmailService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emailService.EmailService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).Update(ctx, req.(*UpdateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _EmailService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchReq)
 	if err := dec(in); err != nil {
@@ -881,6 +2855,128 @@ func _EmailService_Search_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EmailService_GetDeliveryHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliveryHistoryReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).GetDeliveryHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emailService.EmailService/GetDeliveryHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).GetDeliveryHistory(ctx, req.(*GetDeliveryHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_Erase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).Erase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emailService.EmailService/Erase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).Erase(ctx, req.(*EraseReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_CreateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBatchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailServiceServer).CreateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/emailService.EmailService/CreateBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailServiceServer).CreateBatch(ctx, req.(*CreateBatchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailService_CreateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EmailServiceServer).CreateStream(&emailServiceCreateStreamServer{stream})
+}
+
+type EmailService_CreateStreamServer interface {
+	SendAndClose(*CreateStreamRes) error
+	Recv() (*CreateReq, error)
+	grpc.ServerStream
+}
+
+type emailServiceCreateStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *emailServiceCreateStreamServer) SendAndClose(m *CreateStreamRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *emailServiceCreateStreamServer) Recv() (*CreateReq, error) {
+	m := new(CreateReq)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _EmailService_WatchStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStatusReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmailServiceServer).WatchStatus(m, &emailServiceWatchStatusServer{stream})
+}
+
+type EmailService_WatchStatusServer interface {
+	Send(*StatusEvent) error
+	grpc.ServerStream
+}
+
+type emailServiceWatchStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *emailServiceWatchStatusServer) Send(m *StatusEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EmailService_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmailServiceServer).Export(m, &emailServiceExportServer{stream})
+}
+
+type EmailService_ExportServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type emailServiceExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *emailServiceExportServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _EmailService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "emailService.EmailService",
 	HandlerType: (*EmailServiceServer)(nil),
@@ -893,11 +2989,43 @@ var _EmailService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetByID",
 			Handler:    _EmailService_GetByID_Handler,
 		},
+		{
+			MethodName: "Update",
+			Handler:    _EmailService_Update_Handler,
+		},
 		{
 			MethodName: "Search",
 			Handler:    _EmailService_Search_Handler,
 		},
+		{
+			MethodName: "GetDeliveryHistory",
+			Handler:    _EmailService_GetDeliveryHistory_Handler,
+		},
+		{
+			MethodName: "Erase",
+			Handler:    _EmailService_Erase_Handler,
+		},
+		{
+			MethodName: "CreateBatch",
+			Handler:    _EmailService_CreateBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CreateStream",
+			Handler:       _EmailService_CreateStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchStatus",
+			Handler:       _EmailService_WatchStatus_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _EmailService_Export_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "email.proto",
 }
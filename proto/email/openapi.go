@@ -0,0 +1,8 @@
+package emailService
+
+import _ "embed"
+
+// OpenAPISpec OpenAPI v2 spec of EmailService REST gateway generated from email.proto
+//
+//go:embed email.swagger.json
+var OpenAPISpec []byte